@@ -0,0 +1,143 @@
+package checkly
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxAPIResponseSize bounds the responses apiClient reads.
+	maxAPIResponseSize = 16 << 20
+
+	// apiPageSize is the largest page size the list endpoints of the API
+	// accept.
+	apiPageSize = 100
+)
+
+// apiClient makes requests to the Checkly API endpoints the SDK does not
+// cover. It shares the HTTP client of the SDK, so that retries, timeouts and
+// logging apply alike.
+//
+// Unexpected responses are returned as an *apiError, which classifyAPIError
+// passes through.
+type apiClient struct {
+	httpClient *http.Client
+	baseURL    string
+	apiKey     string
+	accountID  string
+}
+
+// apiClientFromMeta returns the apiClient of the provider.
+func apiClientFromMeta(meta any) (*apiClient, error) {
+	m, ok := meta.(*providerMeta)
+	if !ok || m.api == nil {
+		return nil, fmt.Errorf("the Checkly API client is not configured (provider meta is %T)", meta)
+	}
+	return m.api, nil
+}
+
+// get sends a GET request for path and decodes the JSON response into v.
+func (c *apiClient) get(ctx context.Context, path string, query url.Values, v any) error {
+	return c.do(ctx, http.MethodGet, path, query, nil, v)
+}
+
+// post sends body as JSON in a POST request to path and decodes the JSON
+// response into v, unless v is nil.
+func (c *apiClient) post(ctx context.Context, path string, body, v any) error {
+	return c.do(ctx, http.MethodPost, path, nil, body, v)
+}
+
+// delete sends a DELETE request for path.
+func (c *apiClient) delete(ctx context.Context, path string) error {
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// do sends a request for path with body, if it is not nil, encoded as JSON,
+// and decodes the JSON response into v, if it is not nil. Any 2xx status is
+// a success.
+func (c *apiClient) do(ctx context.Context, method, path string, query url.Values, body, v any) error {
+	u := strings.TrimSuffix(c.baseURL, "/") + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request for %s: %w", path, err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to build request for %s: %w", path, err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.accountID != "" {
+		req.Header.Set("X-Checkly-Account", c.accountID)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(io.LimitReader(res.Body, maxAPIResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read response for %s: %w", path, err)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return newAPIError(res.StatusCode, resBody)
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(resBody, v); err != nil {
+		return fmt.Errorf("failed to decode response for %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// listAPIPages fetches every page of the list endpoint at path, which returns
+// a JSON array per page. It stops at the first page that is not full.
+func listAPIPages[T any](ctx context.Context, c *apiClient, path string, query url.Values) ([]T, error) {
+	var all []T
+
+	for page := 1; ; page++ {
+		q := url.Values{}
+		maps.Copy(q, query)
+		q.Set("limit", strconv.Itoa(apiPageSize))
+		q.Set("page", strconv.Itoa(page))
+
+		var items []T
+		if err := c.get(ctx, path, q, &items); err != nil {
+			return nil, err
+		}
+
+		all = append(all, items...)
+
+		if len(items) < apiPageSize {
+			return all, nil
+		}
+	}
+}
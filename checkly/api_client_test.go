@@ -0,0 +1,38 @@
+package checkly
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestAPIClient returns an apiClient that sends its requests to handler.
+func newTestAPIClient(t *testing.T, handler http.HandlerFunc) *apiClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return &apiClient{httpClient: server.Client(), baseURL: server.URL, apiKey: "cu_test"}
+}
+
+func TestAPIClientFromMeta(t *testing.T) {
+	t.Parallel()
+
+	api := &apiClient{apiKey: "cu_test"}
+	got, err := apiClientFromMeta(&providerMeta{api: api})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != api {
+		t.Errorf("got %p, want %p", got, api)
+	}
+
+	for _, meta := range []any{nil, &providerMeta{}, "not a provider"} {
+		_, err := apiClientFromMeta(meta)
+		if err == nil || !strings.Contains(err.Error(), "not configured") {
+			t.Errorf("apiClientFromMeta(%#v): got error %v, want not configured", meta, err)
+		}
+	}
+}
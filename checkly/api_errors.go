@@ -0,0 +1,357 @@
+package checkly
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+// apiErrorKind is the category of an error returned by the Checkly API.
+type apiErrorKind int
+
+const (
+	apiErrorOther apiErrorKind = iota
+	apiErrorNotFound
+	apiErrorUnauthorized
+	apiErrorForbidden
+	apiErrorValidation
+	apiErrorConflict
+	apiErrorRateLimited
+)
+
+func (k apiErrorKind) String() string {
+	switch k {
+	case apiErrorNotFound:
+		return "not found"
+	case apiErrorUnauthorized:
+		return "unauthorized"
+	case apiErrorForbidden:
+		return "forbidden"
+	case apiErrorValidation:
+		return "validation"
+	case apiErrorConflict:
+		return "conflict"
+	case apiErrorRateLimited:
+		return "rate limited"
+	default:
+		return "other"
+	}
+}
+
+// apiFieldError is a validation failure the API attributed to a single field
+// of the request payload, such as "request.url".
+type apiFieldError struct {
+	Field   string
+	Message string
+}
+
+// apiError is the classified form of an error returned by the Checkly API.
+type apiError struct {
+	Kind       apiErrorKind
+	StatusCode int
+
+	// Message is the message from the response body, if it had one.
+	Message string
+
+	// Fields lists the fields rejected by a validation error.
+	Fields []apiFieldError
+}
+
+// apiStatusRegexp matches the error the Checkly SDK returns for an
+// unexpected response, which carries the status and the quoted body. The SDK
+// has no typed error for it; apiClient returns an *apiError instead.
+var apiStatusRegexp = regexp.MustCompile(`unexpected response status (\d{3})(?:: ("(?:[^"\\]|\\.)*"))?`)
+
+// apiErrorBody is the error payload of the Checkly API.
+type apiErrorBody struct {
+	StatusCode int    `json:"statusCode"`
+	Error      string `json:"error"`
+	Message    string `json:"message"`
+	Validation struct {
+		Keys []string `json:"keys"`
+	} `json:"validation"`
+}
+
+// classifyAPIError returns the classification of err, or nil if err does not
+// originate from an API response. Wrapped errors are classified as well.
+// Errors from apiClient already are an *apiError; only errors from the SDK
+// are parsed.
+func classifyAPIError(err error) *apiError {
+	if err == nil {
+		return nil
+	}
+
+	var classified *apiError
+	if errors.As(err, &classified) {
+		return classified
+	}
+
+	if errors.Is(err, checkly.ErrCodeBundleNotFound) {
+		return &apiError{Kind: apiErrorNotFound, StatusCode: http.StatusNotFound}
+	}
+
+	match := apiStatusRegexp.FindStringSubmatch(err.Error())
+	if match == nil {
+		return nil
+	}
+
+	status, _ := strconv.Atoi(match[1])
+
+	if match[2] == "" {
+		return newAPIError(status, nil)
+	}
+
+	raw, err := strconv.Unquote(match[2])
+	if err != nil {
+		return newAPIError(status, nil)
+	}
+
+	return newAPIError(status, []byte(raw))
+}
+
+// newAPIError classifies a response of the API with the given status and
+// body.
+func newAPIError(status int, body []byte) *apiError {
+	e := &apiError{
+		Kind:       apiErrorKindForStatus(status),
+		StatusCode: status,
+	}
+
+	if len(body) == 0 {
+		return e
+	}
+
+	var payload apiErrorBody
+	if err := json.Unmarshal(body, &payload); err != nil {
+		e.Message = strings.TrimSpace(string(body))
+		return e
+	}
+
+	e.Message = payload.Message
+	if e.Kind == apiErrorValidation {
+		e.Fields = apiFieldErrors(payload.Message, payload.Validation.Keys)
+	}
+
+	return e
+}
+
+func (e *apiError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("unexpected response status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("unexpected response status %d: %s", e.StatusCode, e.Message)
+}
+
+func apiErrorKindForStatus(status int) apiErrorKind {
+	switch status {
+	case http.StatusNotFound, http.StatusGone:
+		return apiErrorNotFound
+	case http.StatusUnauthorized:
+		return apiErrorUnauthorized
+	case http.StatusForbidden:
+		return apiErrorForbidden
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return apiErrorValidation
+	case http.StatusConflict:
+		return apiErrorConflict
+	case http.StatusTooManyRequests:
+		return apiErrorRateLimited
+	default:
+		return apiErrorOther
+	}
+}
+
+// apiFieldErrors pairs every rejected key with the sentences of message that
+// mention it. The API quotes the last segment of the key in its messages,
+// e.g. `"url" must be a valid uri`.
+func apiFieldErrors(message string, keys []string) []apiFieldError {
+	sentences := strings.Split(message, ". ")
+
+	fields := make([]apiFieldError, 0, len(keys))
+	for _, key := range keys {
+		label := strconv.Quote(key[strings.LastIndex(key, ".")+1:])
+
+		var mentions []string
+		for _, sentence := range sentences {
+			if strings.Contains(sentence, label) {
+				mentions = append(mentions, strings.TrimSuffix(sentence, "."))
+			}
+		}
+
+		fieldMessage := message
+		if len(mentions) > 0 {
+			fieldMessage = strings.Join(mentions, ". ")
+		}
+
+		fields = append(fields, apiFieldError{Field: key, Message: fieldMessage})
+	}
+
+	return fields
+}
+
+// isNotFoundError reports whether err means the requested object does not
+// exist (anymore).
+func isNotFoundError(err error) bool {
+	e := classifyAPIError(err)
+	return e != nil && e.Kind == apiErrorNotFound
+}
+
+// apiErrorDetail explains how to resolve an error of the given kind.
+func apiErrorDetail(e *apiError) string {
+	switch e.Kind {
+	case apiErrorNotFound:
+		return "The object does not exist in Checkly, or it belongs to another account than the one set by account_id."
+	case apiErrorUnauthorized:
+		return "The Checkly API rejected the credentials. Check the api_key provider setting or the CHECKLY_API_KEY environment variable."
+	case apiErrorForbidden:
+		return "The API key is not allowed to perform this operation. Check the role of the API key, and that account_id names the account the key belongs to."
+	case apiErrorValidation:
+		return "The Checkly API rejected the request as invalid: " + e.Message
+	case apiErrorConflict:
+		return "The request conflicts with the current state in Checkly, for example an object with the same name already exists. Refresh the state and try again."
+	case apiErrorRateLimited:
+		return "The Checkly API rate limit was still exceeded after retrying. Raise max_retries or retry_max_wait, or lower the parallelism of Terraform."
+	default:
+		return ""
+	}
+}
+
+// apiErrorDiagnostics converts err into diagnostics. Validation errors are
+// split into one diagnostic per rejected field, attached to the matching
+// attribute of v, the configuration or state of the resource, where there is
+// one.
+func apiErrorDiagnostics(err error, v cty.Value) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	e := classifyAPIError(err)
+	if e == nil {
+		return diag.FromErr(err)
+	}
+
+	if e.Kind != apiErrorValidation || len(e.Fields) == 0 {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  err.Error(),
+			Detail:   apiErrorDetail(e),
+		}}
+	}
+
+	diags := make(diag.Diagnostics, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Invalid value for %q: %s", field.Field, field.Message),
+			Detail:        err.Error(),
+			AttributePath: apiFieldAttributePath(field.Field, v),
+		})
+	}
+
+	return diags
+}
+
+// apiFieldAttributePath maps a field of an API payload, such as
+// "request.assertions.0.target", to the path of the matching attribute in v.
+// The path stops at the deepest attribute that could be matched, and is nil
+// if not even the first segment matches. Elements of sets cannot be
+// addressed, so a path never descends into a set.
+func apiFieldAttributePath(field string, v cty.Value) cty.Path {
+	var path cty.Path
+
+	segments := strings.Split(field, ".")
+	for i := 0; i < len(segments); i++ {
+		if v.IsNull() || !v.IsKnown() || !v.Type().IsObjectType() {
+			break
+		}
+
+		name := snakeCase(segments[i])
+		if !v.Type().HasAttribute(name) {
+			break
+		}
+		path = path.GetAttr(name)
+		v = v.GetAttr(name)
+
+		if v.IsNull() || !v.IsKnown() || !v.Type().IsListType() {
+			break
+		}
+
+		// Blocks limited to a single element are addressed without an index
+		// by the API.
+		index, err := strconv.Atoi(nextSegment(segments, i))
+		switch {
+		case err == nil:
+			i++
+		case v.LengthInt() == 1:
+			index = 0
+		default:
+			return path
+		}
+		if index < 0 || index >= v.LengthInt() {
+			return path
+		}
+
+		path = path.IndexInt(index)
+		v = v.Index(cty.NumberIntVal(int64(index)))
+	}
+
+	return path
+}
+
+func nextSegment(segments []string, i int) string {
+	if i+1 < len(segments) {
+		return segments[i+1]
+	}
+	return ""
+}
+
+// snakeCase converts an API field name such as "degradedResponseTime" to the
+// attribute naming used by the provider.
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// withAPIErrors adapts a CRUD function that reports failures as errors to the
+// context-aware signature of the SDK, reporting the errors through
+// apiErrorDiagnostics.
+func withAPIErrors(
+	f func(ctx context.Context, d *schema.ResourceData, client any) error,
+) func(context.Context, *schema.ResourceData, any) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, client any) diag.Diagnostics {
+		err := f(ctx, d, client)
+		if err == nil {
+			return nil
+		}
+
+		// The configuration is null when destroying, and the state when
+		// creating.
+		v := d.GetRawConfig()
+		if v.IsNull() {
+			v = d.GetRawState()
+		}
+
+		return apiErrorDiagnostics(err, v)
+	}
+}
@@ -0,0 +1,235 @@
+package checkly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+// sdkResponseError builds an error the way the SDK reports an unexpected
+// response.
+func sdkResponseError(status int, body string) error {
+	return fmt.Errorf("unexpected response status %d: %q", status, body)
+}
+
+func TestClassifyAPIError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		err         error
+		wantNil     bool
+		wantKind    apiErrorKind
+		wantMessage string
+		wantFields  []apiFieldError
+	}{
+		{
+			name:    "not an API error",
+			err:     errors.New("dial tcp: connection refused"),
+			wantNil: true,
+		},
+		{
+			name:    "port number is not mistaken for a status",
+			err:     errors.New("dial tcp 127.0.0.1:404: connection refused"),
+			wantNil: true,
+		},
+		{
+			name:        "not found",
+			err:         fmt.Errorf("failed to retrieve check %q: %w", "abc", sdkResponseError(404, `{"statusCode":404,"error":"Not Found","message":"Not Found"}`)),
+			wantKind:    apiErrorNotFound,
+			wantMessage: "Not Found",
+		},
+		{
+			name:     "missing code bundle",
+			err:      fmt.Errorf("failed to peek code bundle: %w", checkly.ErrCodeBundleNotFound),
+			wantKind: apiErrorNotFound,
+		},
+		{
+			name:        "unauthorized",
+			err:         sdkResponseError(401, `{"statusCode":401,"error":"Unauthorized","message":"Bad token"}`),
+			wantKind:    apiErrorUnauthorized,
+			wantMessage: "Bad token",
+		},
+		{
+			name:        "forbidden",
+			err:         sdkResponseError(403, `{"statusCode":403,"error":"Forbidden","message":"Forbidden"}`),
+			wantKind:    apiErrorForbidden,
+			wantMessage: "Forbidden",
+		},
+		{
+			name:        "conflict",
+			err:         sdkResponseError(409, `{"statusCode":409,"error":"Conflict","message":"Key already exists"}`),
+			wantKind:    apiErrorConflict,
+			wantMessage: "Key already exists",
+		},
+		{
+			name:     "rate limited",
+			err:      sdkResponseError(429, ``),
+			wantKind: apiErrorRateLimited,
+		},
+		{
+			name:        "plain text body",
+			err:         sdkResponseError(502, "Bad Gateway\n"),
+			wantKind:    apiErrorOther,
+			wantMessage: "Bad Gateway",
+		},
+		{
+			name: "validation",
+			err: sdkResponseError(400, `{"statusCode":400,"error":"Bad Request",`+
+				`"message":"\"frequency\" must be one of [1, 5, 10]. \"url\" must be a valid uri",`+
+				`"validation":{"source":"payload","keys":["frequency","request.url"]}}`),
+			wantKind:    apiErrorValidation,
+			wantMessage: `"frequency" must be one of [1, 5, 10]. "url" must be a valid uri`,
+			wantFields: []apiFieldError{
+				{Field: "frequency", Message: `"frequency" must be one of [1, 5, 10]`},
+				{Field: "request.url", Message: `"url" must be a valid uri`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := classifyAPIError(tt.err)
+			if tt.wantNil {
+				if got != nil {
+					t.Fatalf("classifyAPIError() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("classifyAPIError() = nil")
+			}
+			if got.Kind != tt.wantKind {
+				t.Errorf("Kind = %v, want %v", got.Kind, tt.wantKind)
+			}
+			if got.Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", got.Message, tt.wantMessage)
+			}
+			if diff := cmp.Diff(tt.wantFields, got.Fields); diff != "" {
+				t.Errorf("Fields mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAPIClientReturnsTypedErrors(t *testing.T) {
+	t.Parallel()
+
+	api := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"statusCode":400,"error":"Bad Request","message":"\"url\" must be a valid uri","validation":{"keys":["request.url"]}}`))
+	})
+
+	err := api.get(context.Background(), "/v1/checks", nil, nil)
+
+	var got *apiError
+	if !errors.As(fmt.Errorf("failed to list checks: %w", err), &got) {
+		t.Fatalf("error = %v, want an *apiError", err)
+	}
+	want := &apiError{
+		Kind:       apiErrorValidation,
+		StatusCode: http.StatusBadRequest,
+		Message:    `"url" must be a valid uri`,
+		Fields:     []apiFieldError{{Field: "request.url", Message: `"url" must be a valid uri`}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("apiError mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestIsNotFoundError(t *testing.T) {
+	t.Parallel()
+
+	for status, want := range map[int]bool{404: true, 410: true, 400: false, 500: false} {
+		t.Run(strconv.Itoa(status), func(t *testing.T) {
+			t.Parallel()
+			if got := isNotFoundError(sdkResponseError(status, "")); got != want {
+				t.Errorf("isNotFoundError(%d) = %v, want %v", status, got, want)
+			}
+		})
+	}
+	if isNotFoundError(nil) {
+		t.Error("isNotFoundError(nil) = true")
+	}
+}
+
+func TestAPIErrorDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	config := cty.ObjectVal(map[string]cty.Value{
+		"degraded_response_time": cty.NumberIntVal(40000),
+		"request": cty.SetVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"url": cty.StringVal("not a url"),
+		})}),
+		"alert_settings": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"reminders": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				"interval": cty.NumberIntVal(7),
+			})}),
+		})}),
+		"locations": cty.ListVal([]cty.Value{cty.StringVal("eu-west-1"), cty.StringVal("mars-1")}),
+	})
+
+	err := fmt.Errorf("failed to create check: %w", sdkResponseError(400, `{"statusCode":400,`+
+		`"message":"\"degradedResponseTime\" must be less than or equal to 30000. \"url\" must be a valid uri. `+
+		`\"interval\" must be one of [5, 10]. \"1\" must be a valid location",`+
+		`"validation":{"keys":["degradedResponseTime","request.url","alertSettings.reminders.interval","locations.1","unknown"]}}`))
+
+	diags := apiErrorDiagnostics(err, config)
+
+	wantPaths := []cty.Path{
+		cty.GetAttrPath("degraded_response_time"),
+		// request is a set, whose elements cannot be addressed.
+		cty.GetAttrPath("request"),
+		cty.GetAttrPath("alert_settings").IndexInt(0).GetAttr("reminders").IndexInt(0).GetAttr("interval"),
+		cty.GetAttrPath("locations").IndexInt(1),
+		nil,
+	}
+	if len(diags) != len(wantPaths) {
+		t.Fatalf("got %d diagnostics, want %d: %v", len(diags), len(wantPaths), diags)
+	}
+	for i, want := range wantPaths {
+		if !diags[i].AttributePath.Equals(want) {
+			t.Errorf("diagnostic %d: AttributePath = %#v, want %#v", i, diags[i].AttributePath, want)
+		}
+		if diags[i].Severity != diag.Error {
+			t.Errorf("diagnostic %d: Severity = %v, want error", i, diags[i].Severity)
+		}
+	}
+	if want := `Invalid value for "request.url": "url" must be a valid uri`; diags[1].Summary != want {
+		t.Errorf("Summary = %q, want %q", diags[1].Summary, want)
+	}
+
+	diags = apiErrorDiagnostics(sdkResponseError(401, `{"message":"Bad token"}`), config)
+	if len(diags) != 1 || diags[0].Detail != apiErrorDetail(&apiError{Kind: apiErrorUnauthorized}) {
+		t.Errorf("unauthorized diagnostics = %v", diags)
+	}
+
+	if diags := apiErrorDiagnostics(errors.New("translation error"), cty.NilVal); len(diags) != 1 || diags[0].Summary != "translation error" {
+		t.Errorf("plain error diagnostics = %v", diags)
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	t.Parallel()
+
+	for in, want := range map[string]string{
+		"frequency":            "frequency",
+		"degradedResponseTime": "degraded_response_time",
+		"privateLocations":     "private_locations",
+	} {
+		if got := snakeCase(in); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
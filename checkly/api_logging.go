@@ -0,0 +1,236 @@
+package checkly
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// apiLogSubsystem is the logging subsystem requests to the Checkly API
+	// are traced in. Its level can be set apart from the rest of the
+	// provider with the TF_LOG_PROVIDER_CHECKLY_API environment variable.
+	apiLogSubsystem = "checkly_api"
+
+	// maxLoggedBodySize bounds the bodies that are logged. Larger bodies are
+	// left out of the log.
+	maxLoggedBodySize = 64 << 10
+
+	redacted = "***"
+)
+
+// requestIDHeaders are the response headers that identify a request to
+// Checkly support, in the order they are looked for.
+var requestIDHeaders = []string{"X-Request-Id", "X-Amzn-Requestid"}
+
+// privateKeyRegexp matches PEM encoded private keys, such as those of client
+// certificates, wherever they show up in a logged value.
+var privateKeyRegexp = regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`)
+
+// secretBodyFields are the JSON fields whose values are always redacted from
+// logged bodies.
+var secretBodyFields = map[string]bool{
+	"apiKey":        true,
+	"passphrase":    true,
+	"privateKey":    true,
+	"rawKey":        true,
+	"webhookSecret": true,
+}
+
+// loggingTransport is an http.RoundTripper that traces every request to the
+// Checkly API in the checkly_api logging subsystem. Method, path, status,
+// latency and request ID are logged at debug level; JSON bodies at trace
+// level, with secrets redacted.
+//
+// It sits at the bottom of the transport stack, so that every attempt a
+// retryTransport makes is logged.
+type loggingTransport struct {
+	next   http.RoundTripper
+	apiKey string
+}
+
+func newLoggingTransport(next http.RoundTripper, apiKey string) *loggingTransport {
+	return &loggingTransport{
+		next:   next,
+		apiKey: apiKey,
+	}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := t.logContext(req.Context())
+
+	fields := map[string]any{
+		"http_method": req.Method,
+		"http_path":   req.URL.Path,
+	}
+
+	tflog.SubsystemDebug(ctx, apiLogSubsystem, "Sending request to the Checkly API", fields)
+
+	if body, ok := requestBodyForLog(req); ok {
+		tflog.SubsystemTrace(ctx, apiLogSubsystem, "Request body", map[string]any{
+			"http_method": req.Method,
+			"http_path":   req.URL.Path,
+			"http_body":   body,
+		})
+	}
+
+	start := time.Now()
+	res, err := t.next.RoundTrip(req)
+	fields["duration_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(ctx, apiLogSubsystem, "Request to the Checkly API failed", fields)
+		return nil, err
+	}
+
+	fields["http_status"] = res.StatusCode
+	for _, header := range requestIDHeaders {
+		if id := res.Header.Get(header); id != "" {
+			fields["request_id"] = id
+			break
+		}
+	}
+
+	tflog.SubsystemDebug(ctx, apiLogSubsystem, "Received response from the Checkly API", fields)
+
+	if body, ok := responseBodyForLog(res); ok {
+		tflog.SubsystemTrace(ctx, apiLogSubsystem, "Response body", map[string]any{
+			"http_method": req.Method,
+			"http_path":   req.URL.Path,
+			"http_status": res.StatusCode,
+			"http_body":   body,
+		})
+	}
+
+	return res, nil
+}
+
+// logContext sets up the checkly_api subsystem in ctx. The API key is masked
+// wherever it shows up, and so is the Authorization header, should it ever
+// be logged.
+func (t *loggingTransport) logContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, apiLogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_CHECKLY_API"))
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, apiLogSubsystem, "Authorization", "authorization")
+	ctx = tflog.SubsystemMaskAllFieldValuesRegexes(ctx, apiLogSubsystem, privateKeyRegexp)
+	if t.apiKey != "" {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, apiLogSubsystem, t.apiKey)
+		ctx = tflog.SubsystemMaskMessageStrings(ctx, apiLogSubsystem, t.apiKey)
+	}
+	return ctx
+}
+
+// requestBodyForLog returns the redacted body of req, if it is JSON. The body
+// is read from a copy, so req is left untouched; requests whose body cannot
+// be copied are not logged.
+func requestBodyForLog(req *http.Request) (string, bool) {
+	if req.GetBody == nil || req.ContentLength > maxLoggedBodySize || !isJSONContentType(req.Header.Get("Content-Type")) {
+		return "", false
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return "", false
+	}
+	defer body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(body, maxLoggedBodySize+1))
+	if err != nil || len(content) > maxLoggedBodySize {
+		return "", false
+	}
+
+	return redactBody(content), true
+}
+
+// responseBodyForLog returns the redacted body of res, if it is JSON. The body
+// is buffered and put back, so that it can still be read by the caller.
+func responseBodyForLog(res *http.Response) (string, bool) {
+	if res.Body == nil || res.ContentLength > maxLoggedBodySize || !isJSONContentType(res.Header.Get("Content-Type")) {
+		return "", false
+	}
+
+	content, err := io.ReadAll(io.LimitReader(res.Body, maxLoggedBodySize+1))
+	res.Body = &readCloser{
+		Reader: io.MultiReader(bytes.NewReader(content), res.Body),
+		Closer: res.Body,
+	}
+	if err != nil || len(content) > maxLoggedBodySize {
+		return "", false
+	}
+
+	return redactBody(content), true
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || mediaType == "application/problem+json")
+}
+
+// redactBody returns a JSON body with its secrets replaced: the fields in
+// secretBodyFields, and the values of secret or locked environment
+// variables. A body that is not valid JSON is logged with only private keys
+// redacted.
+func redactBody(content []byte) string {
+	var v any
+	if err := json.Unmarshal(content, &v); err != nil {
+		return privateKeyRegexp.ReplaceAllString(string(content), redacted)
+	}
+
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return ""
+	}
+
+	return string(out)
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		// Environment variables look like {"key": ..., "value": ...,
+		// "secret": true}.
+		if isSecretEnvironmentVariable(v) {
+			if _, ok := v["value"]; ok {
+				v["value"] = redacted
+			}
+		}
+		for key, value := range v {
+			if secretBodyFields[key] {
+				if value != nil && value != "" {
+					v[key] = redacted
+				}
+				continue
+			}
+			v[key] = redactValue(value)
+		}
+		return v
+	case []any:
+		for i, value := range v {
+			v[i] = redactValue(value)
+		}
+		return v
+	case string:
+		return privateKeyRegexp.ReplaceAllString(v, redacted)
+	default:
+		return v
+	}
+}
+
+func isSecretEnvironmentVariable(v map[string]any) bool {
+	if _, ok := v["key"]; !ok {
+		return false
+	}
+	return v["secret"] == true || v["locked"] == true
+}
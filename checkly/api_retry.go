@@ -0,0 +1,160 @@
+package checkly
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxRetries   = 3
+	defaultRetryMaxWait = 30 * time.Second
+
+	// retryBaseWait is the backoff before the first retry. It doubles with
+	// every further attempt.
+	retryBaseWait = time.Second
+)
+
+// retryTransport is an http.RoundTripper that retries requests the Checkly
+// API rejected because of rate limiting or a transient failure.
+//
+// A 429 response is retried for every method, since the request was not
+// processed. Server errors and network failures are only retried for
+// idempotent methods, as a POST may have taken effect before failing.
+// Requests whose body cannot be replayed are never retried.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	maxWait    time.Duration
+
+	// sleep waits for d or until ctx is done. It is replaced in tests.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func newRetryTransport(next http.RoundTripper, maxRetries int, maxWait time.Duration) *retryTransport {
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		maxWait:    maxWait,
+		sleep:      sleepContext,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		res, err := t.next.RoundTrip(req)
+
+		if attempt >= t.maxRetries || !replayable || !shouldRetryRequest(req, res, err) {
+			return res, err
+		}
+
+		wait, ok := t.retryWait(res, attempt)
+		if !ok {
+			// The server asked us to wait longer than allowed; retrying
+			// sooner would only be rejected again.
+			return res, err
+		}
+
+		if res != nil {
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		if err := t.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// shouldRetryRequest reports whether the outcome of req is worth retrying.
+func shouldRetryRequest(req *http.Request, res *http.Response, err error) bool {
+	if err != nil {
+		// A canceled or expired context is final.
+		return req.Context().Err() == nil && isIdempotentMethod(req.Method)
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return isIdempotentMethod(req.Method)
+	}
+
+	return false
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryWait returns how long to wait before the next attempt. A Retry-After
+// header is honored; otherwise the wait is an exponential backoff with full
+// jitter. It reports false if Retry-After asks for more than maxWait.
+func (t *retryTransport) retryWait(res *http.Response, attempt int) (time.Duration, bool) {
+	if res != nil {
+		if wait, ok := parseRetryAfter(res.Header.Get("Retry-After"), time.Now()); ok {
+			return wait, wait <= t.maxWait
+		}
+	}
+
+	backoff := t.maxWait
+	if attempt < 30 && retryBaseWait<<attempt < backoff {
+		backoff = retryBaseWait << attempt
+	}
+
+	return rand.N(backoff + 1), true
+}
+
+// parseRetryAfter parses a Retry-After header, which holds either a number
+// of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
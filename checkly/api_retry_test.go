@@ -0,0 +1,231 @@
+package checkly
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// retryTestServer answers every request with the next status in statuses,
+// repeating the last one, and records the request bodies it received.
+func retryTestServer(t *testing.T, header http.Header, statuses ...int) (*httptest.Server, *atomic.Int32, *[]string) {
+	t.Helper()
+
+	var calls atomic.Int32
+	var bodies []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1)) - 1
+		if n >= len(statuses) {
+			n = len(statuses) - 1
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		for k, v := range header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(statuses[n])
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &calls, &bodies
+}
+
+func TestRetryTransport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		method     string
+		header     http.Header
+		statuses   []int
+		maxRetries int
+		maxWait    time.Duration
+		wantStatus int
+		wantCalls  int32
+		wantWaits  []time.Duration
+	}{
+		{
+			name:       "success is not retried",
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusOK},
+			maxRetries: 3,
+			maxWait:    time.Minute,
+			wantStatus: http.StatusOK,
+			wantCalls:  1,
+		},
+		{
+			name:       "rate limited POST is retried after Retry-After",
+			method:     http.MethodPost,
+			header:     http.Header{"Retry-After": []string{"2"}},
+			statuses:   []int{http.StatusTooManyRequests, http.StatusCreated},
+			maxRetries: 3,
+			maxWait:    time.Minute,
+			wantStatus: http.StatusCreated,
+			wantCalls:  2,
+			wantWaits:  []time.Duration{2 * time.Second},
+		},
+		{
+			name:       "Retry-After beyond the maximum wait is not retried",
+			method:     http.MethodGet,
+			header:     http.Header{"Retry-After": []string{"120"}},
+			statuses:   []int{http.StatusTooManyRequests},
+			maxRetries: 3,
+			maxWait:    time.Minute,
+			wantStatus: http.StatusTooManyRequests,
+			wantCalls:  1,
+		},
+		{
+			name:       "server error on GET is retried until the limit",
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusBadGateway},
+			maxRetries: 2,
+			maxWait:    time.Minute,
+			wantStatus: http.StatusBadGateway,
+			wantCalls:  3,
+		},
+		{
+			name:       "server error on POST is not retried",
+			method:     http.MethodPost,
+			statuses:   []int{http.StatusServiceUnavailable},
+			maxRetries: 3,
+			maxWait:    time.Minute,
+			wantStatus: http.StatusServiceUnavailable,
+			wantCalls:  1,
+		},
+		{
+			name:       "client error is not retried",
+			method:     http.MethodPut,
+			statuses:   []int{http.StatusBadRequest},
+			maxRetries: 3,
+			maxWait:    time.Minute,
+			wantStatus: http.StatusBadRequest,
+			wantCalls:  1,
+		},
+		{
+			name:       "retries disabled",
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusTooManyRequests},
+			maxRetries: 0,
+			maxWait:    time.Minute,
+			wantStatus: http.StatusTooManyRequests,
+			wantCalls:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, calls, bodies := retryTestServer(t, tt.header, tt.statuses...)
+
+			var waits []time.Duration
+			transport := newRetryTransport(http.DefaultTransport, tt.maxRetries, tt.maxWait)
+			transport.sleep = func(ctx context.Context, d time.Duration) error {
+				waits = append(waits, d)
+				return nil
+			}
+
+			req, err := http.NewRequest(tt.method, server.URL, strings.NewReader("payload"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := (&http.Client{Transport: transport}).Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			res.Body.Close()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", got, tt.wantCalls)
+			}
+			for i, body := range *bodies {
+				if body != "payload" {
+					t.Errorf("body of attempt %d = %q, want %q", i, body, "payload")
+				}
+			}
+			if len(waits) != int(tt.wantCalls)-1 {
+				t.Errorf("waited %d times, want %d", len(waits), tt.wantCalls-1)
+			}
+			for i, want := range tt.wantWaits {
+				if i < len(waits) && waits[i] != want {
+					t.Errorf("wait %d = %v, want %v", i, waits[i], want)
+				}
+			}
+			for i, wait := range waits {
+				if wait > tt.maxWait {
+					t.Errorf("wait %d = %v exceeds the maximum of %v", i, wait, tt.maxWait)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryTransportStopsWhenContextIsDone(t *testing.T) {
+	t.Parallel()
+
+	server, calls, _ := retryTestServer(t, nil, http.StatusServiceUnavailable)
+
+	transport := newRetryTransport(http.DefaultTransport, 5, time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	transport.sleep = func(ctx context.Context, d time.Duration) error {
+		return sleepContext(ctx, time.Hour)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := (&http.Client{Transport: transport}).Do(req); err == nil {
+		t.Fatal("request should fail once the context is done")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("calls = %d, want 1", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOk bool
+	}{
+		{"", 0, false},
+		{"0", 0, true},
+		{"7", 7 * time.Second, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := parseRetryAfter(tt.value, now)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("parseRetryAfter(%q) = (%v, %v), want (%v, %v)", tt.value, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
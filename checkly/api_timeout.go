@@ -0,0 +1,66 @@
+package checkly
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// timeoutTransport is an http.RoundTripper that bounds every request to the
+// Checkly API by the request_timeout provider setting. It sits below
+// retryTransport, so that each attempt gets the full timeout.
+//
+// The timeout covers reading the response body as well; it is released when
+// the body is closed.
+type timeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func newTimeoutTransport(next http.RoundTripper, timeout time.Duration) *timeoutTransport {
+	return &timeoutTransport{
+		next:    next,
+		timeout: timeout,
+	}
+}
+
+type withoutRequestTimeoutKey struct{}
+
+// withoutRequestTimeout marks requests made with ctx as exempt from the
+// request timeout. They are only bounded by ctx itself, which is how code
+// bundle uploads, whose duration depends on their size, are bounded by the
+// create timeout of the resource instead.
+func withoutRequestTimeout(ctx context.Context) context.Context {
+	return context.WithValue(ctx, withoutRequestTimeoutKey{}, true)
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 || req.Context().Value(withoutRequestTimeoutKey{}) != nil {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+
+	res, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	res.Body = &cancelOnCloseBody{ReadCloser: res.Body, cancel: cancel}
+
+	return res, nil
+}
+
+// cancelOnCloseBody releases the context of a request once its response body
+// is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
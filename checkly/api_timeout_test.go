@@ -0,0 +1,109 @@
+package checkly
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeoutTransport(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}
+		io.WriteString(w, "ok")
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{Transport: newTimeoutTransport(http.DefaultTransport, 50*time.Millisecond)}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		path    string
+		wantErr bool
+	}{
+		{"fast request", context.Background(), "/", false},
+		{"slow request", context.Background(), "/slow", true},
+		{"slow request without request timeout", withoutRequestTimeout(context.Background()), "/slow", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequestWithContext(tt.ctx, http.MethodGet, server.URL+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := client.Do(req)
+			if tt.wantErr {
+				if !errors.Is(err, context.DeadlineExceeded) {
+					t.Fatalf("error = %v, want %v", err, context.DeadlineExceeded)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			defer res.Body.Close()
+
+			// The body must still be readable after RoundTrip returned.
+			body, err := io.ReadAll(res.Body)
+			if err != nil || string(body) != "ok" {
+				t.Errorf("body = %q, %v, want %q", body, err, "ok")
+			}
+		})
+	}
+}
+
+func TestRequestTimeoutDefault(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    any
+		wantErr string
+	}{
+		{"default", nil, 15, ""},
+		{"request timeout", map[string]string{"CHECKLY_REQUEST_TIMEOUT": "60"}, 60, ""},
+		{"legacy variable", map[string]string{"API_CALL_TIMEOUT": "30"}, 30, ""},
+		{"new variable wins", map[string]string{"CHECKLY_REQUEST_TIMEOUT": "60", "API_CALL_TIMEOUT": "30"}, 60, ""},
+		{"invalid", map[string]string{"API_CALL_TIMEOUT": "15s"}, nil, `invalid API_CALL_TIMEOUT value "15s"`},
+		{"not positive", map[string]string{"CHECKLY_REQUEST_TIMEOUT": "0"}, nil, `invalid CHECKLY_REQUEST_TIMEOUT value "0"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CHECKLY_REQUEST_TIMEOUT", "")
+			t.Setenv("API_CALL_TIMEOUT", "")
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			got, err := requestTimeoutDefault()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("requestTimeoutDefault() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("requestTimeoutDefault failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("requestTimeoutDefault() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
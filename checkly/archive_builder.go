@@ -0,0 +1,398 @@
+package checkly
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// defaultArchiveExcludes lists paths that never belong in a code bundle.
+// Dependencies are installed by the Checkly backend, and the rest are VCS
+// metadata or Playwright output left behind by local runs.
+var defaultArchiveExcludes = []string{
+	".git/",
+	"node_modules/",
+	"test-results/",
+	"playwright-report/",
+	"blob-report/",
+}
+
+// archiveBuilderOptions controls which files buildArchiveFromDirectory packs.
+type archiveBuilderOptions struct {
+	// Include lists patterns selecting the files to pack. When empty, every
+	// file is a candidate.
+	Include []string
+
+	// Exclude lists patterns removing files from the candidates. They are
+	// evaluated after defaultArchiveExcludes, so a negated pattern (such as
+	// "!test-results/") can bring a default exclusion back.
+	Exclude []string
+
+	// RespectGitignore applies every .gitignore found in the directory tree,
+	// scoped to the directory that holds it.
+	RespectGitignore bool
+}
+
+// buildArchiveFromDirectory writes a gzip-compressed tar archive of root to w.
+//
+// The output is deterministic: entries are written in lexical path order,
+// modification times are zeroed, ownership is cleared and permissions are
+// normalized, so packing the same content twice yields byte-identical
+// archives regardless of checkout time, user or umask. Only regular files
+// and symbolic links are packed; directories are implied by their contents.
+func buildArchiveFromDirectory(root string, w io.Writer, opts archiveBuilderOptions) error {
+	info, err := os.Stat(root)
+	if err != nil {
+		return fmt.Errorf("failed to stat source directory %q: %w", root, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("source directory %q is not a directory", root)
+	}
+
+	include, err := compileArchivePatterns("", opts.Include)
+	if err != nil {
+		return fmt.Errorf("invalid include pattern: %w", err)
+	}
+
+	exclude, err := compileArchivePatterns("", append(append([]string{}, defaultArchiveExcludes...), opts.Exclude...))
+	if err != nil {
+		return fmt.Errorf("invalid exclude pattern: %w", err)
+	}
+
+	gzw, err := gzip.NewWriterLevel(w, gzip.BestCompression)
+	if err != nil {
+		return fmt.Errorf("failed to create gzip writer: %w", err)
+	}
+
+	tw := tar.NewWriter(gzw)
+
+	// Rules from every .gitignore seen so far. filepath.WalkDir visits a
+	// directory before its contents, so a directory's own .gitignore is
+	// loaded before any of its entries are matched against it.
+	var gitignores []archivePattern
+
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("failed to read %q: %w", p, err)
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if rel == "." {
+			if opts.RespectGitignore {
+				gitignores, err = appendGitignorePatterns(gitignores, p, "")
+			}
+			return err
+		}
+
+		isDir := d.IsDir()
+
+		if opts.RespectGitignore && matchArchivePatterns(gitignores, rel, isDir) {
+			if isDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if matchArchivePatterns(exclude, rel, isDir) {
+			if isDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if isDir {
+			if opts.RespectGitignore {
+				gitignores, err = appendGitignorePatterns(gitignores, p, rel)
+			}
+			return err
+		}
+
+		if len(include) > 0 && !matchArchivePatternsOrAncestor(include, rel) {
+			return nil
+		}
+
+		return writeArchiveEntry(tw, p, rel, d)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize tar archive: %w", err)
+	}
+
+	if err := gzw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize gzip stream: %w", err)
+	}
+
+	return nil
+}
+
+// writeArchiveEntry appends a single file or symbolic link to tw. Other file
+// types (sockets, devices, named pipes) are skipped, since they cannot be
+// meaningfully shipped in a code bundle.
+func writeArchiveEntry(tw *tar.Writer, p string, rel string, d fs.DirEntry) error {
+	info, err := d.Info()
+	if err != nil {
+		return fmt.Errorf("failed to stat %q: %w", p, err)
+	}
+
+	hdr := &tar.Header{
+		Name:    rel,
+		ModTime: time.Unix(0, 0),
+	}
+
+	switch {
+	case info.Mode()&fs.ModeSymlink != 0:
+		target, err := os.Readlink(p)
+		if err != nil {
+			return fmt.Errorf("failed to read symbolic link %q: %w", p, err)
+		}
+		hdr.Typeflag = tar.TypeSymlink
+		hdr.Linkname = filepath.ToSlash(target)
+		hdr.Mode = 0777
+	case info.Mode().IsRegular():
+		hdr.Typeflag = tar.TypeReg
+		hdr.Size = info.Size()
+		hdr.Mode = 0644
+		if info.Mode().Perm()&0111 != 0 {
+			hdr.Mode = 0755
+		}
+	default:
+		return nil
+	}
+
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("failed to write tar header for %q: %w", rel, err)
+	}
+
+	if hdr.Typeflag != tar.TypeReg {
+		return nil
+	}
+
+	file, err := os.Open(p)
+	if err != nil {
+		return fmt.Errorf("failed to open %q: %w", p, err)
+	}
+	defer file.Close()
+
+	// Copy exactly the size recorded in the header, so a file that grows
+	// while it is being packed cannot corrupt the archive.
+	if _, err := io.CopyN(tw, file, hdr.Size); err != nil {
+		return fmt.Errorf("failed to write %q to archive: %w", rel, err)
+	}
+
+	return nil
+}
+
+// archivePattern is a single compiled pattern in .gitignore syntax.
+type archivePattern struct {
+	// base is the slash-separated directory the pattern is relative to, or
+	// an empty string for the archive root.
+	base string
+
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// compileArchivePatterns compiles .gitignore-style patterns relative to base.
+// Blank lines and comments are skipped.
+func compileArchivePatterns(base string, patterns []string) ([]archivePattern, error) {
+	var compiled []archivePattern
+
+	for _, raw := range patterns {
+		p, ok, err := compileArchivePattern(base, raw)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", raw, err)
+		}
+		if ok {
+			compiled = append(compiled, p)
+		}
+	}
+
+	return compiled, nil
+}
+
+// compileArchivePattern compiles one pattern. The boolean result is false for
+// lines that carry no pattern, such as blank lines and comments.
+func compileArchivePattern(base string, raw string) (archivePattern, bool, error) {
+	s := strings.TrimRight(raw, " \t\r")
+	if s == "" || strings.HasPrefix(s, "#") {
+		return archivePattern{}, false, nil
+	}
+
+	p := archivePattern{base: base}
+
+	if strings.HasPrefix(s, "!") {
+		p.negate = true
+		s = s[1:]
+	}
+	s = strings.TrimPrefix(s, `\`)
+
+	if strings.HasSuffix(s, "/") {
+		p.dirOnly = true
+		s = strings.TrimRight(s, "/")
+	}
+
+	if s == "" {
+		return archivePattern{}, false, nil
+	}
+
+	// A pattern with a separator anywhere but at the end is anchored to its
+	// base directory; one without matches at any depth.
+	anchored := strings.Contains(s, "/")
+	s = strings.TrimPrefix(s, "/")
+	if !anchored && !strings.HasPrefix(s, "**") {
+		s = "**/" + s
+	}
+
+	re, err := regexp.Compile("^" + globToRegexp(s) + "$")
+	if err != nil {
+		return archivePattern{}, false, err
+	}
+	p.re = re
+
+	return p, true, nil
+}
+
+// globToRegexp translates a glob into a regular expression. "*" and "?" never
+// cross a path separator, while "**" spans any number of directories.
+func globToRegexp(glob string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				i++
+				if i+1 < len(glob) && glob[i+1] == '/' {
+					// "**/" matches zero or more leading directories.
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+				continue
+			}
+			b.WriteString("[^/]*")
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				b.WriteString(regexp.QuoteMeta(string(glob[i])))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	return b.String()
+}
+
+// match reports whether the pattern applies to rel, a slash-separated path
+// relative to the archive root.
+func (p archivePattern) match(rel string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+
+	if p.base != "" {
+		if !strings.HasPrefix(rel, p.base+"/") {
+			return false
+		}
+		rel = rel[len(p.base)+1:]
+	}
+
+	return p.re.MatchString(rel)
+}
+
+// matchArchivePatterns evaluates patterns in order and reports whether rel is
+// matched. As in .gitignore, the last matching pattern wins, so a negated
+// pattern can undo an earlier match.
+func matchArchivePatterns(patterns []archivePattern, rel string, isDir bool) bool {
+	matched := false
+
+	for _, p := range patterns {
+		if p.match(rel, isDir) {
+			matched = !p.negate
+		}
+	}
+
+	return matched
+}
+
+// matchArchivePatternsOrAncestor reports whether the file at rel, or any
+// directory that contains it, is matched by patterns. This lets an include
+// pattern naming a directory select everything below it.
+func matchArchivePatternsOrAncestor(patterns []archivePattern, rel string) bool {
+	if matchArchivePatterns(patterns, rel, false) {
+		return true
+	}
+
+	for dir := path.Dir(rel); dir != "."; dir = path.Dir(dir) {
+		if matchArchivePatterns(patterns, dir, true) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// appendGitignorePatterns loads the .gitignore in dir, if there is one, and
+// appends its patterns scoped to rel, the directory's path relative to the
+// archive root.
+func appendGitignorePatterns(patterns []archivePattern, dir string, rel string) ([]archivePattern, error) {
+	file, err := os.Open(filepath.Join(dir, ".gitignore"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return patterns, nil
+		}
+		return nil, fmt.Errorf("failed to open .gitignore in %q: %w", dir, err)
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read .gitignore in %q: %w", dir, err)
+	}
+
+	compiled, err := compileArchivePatterns(rel, lines)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern in .gitignore in %q: %w", dir, err)
+	}
+
+	return append(patterns, compiled...), nil
+}
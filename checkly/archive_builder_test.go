@@ -0,0 +1,307 @@
+package checkly
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// writeSourceTree creates the given files below a fresh temporary directory
+// and returns its path.
+func writeSourceTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	root := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("mkdir %q: %v", name, err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatalf("write %q: %v", name, err)
+		}
+	}
+	return root
+}
+
+func buildArchiveBytes(t *testing.T, root string, opts archiveBuilderOptions) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := buildArchiveFromDirectory(root, &buf, opts); err != nil {
+		t.Fatalf("buildArchiveFromDirectory failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// archiveEntryNames lists the entry names of a tar.gz archive in order.
+func archiveEntryNames(t *testing.T, archive []byte) []string {
+	t.Helper()
+
+	gzr, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatalf("gzip reader: %v", err)
+	}
+	tr := tar.NewReader(gzr)
+
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("read archive: %v", err)
+		}
+		names = append(names, hdr.Name)
+	}
+	return names
+}
+
+func TestBuildArchiveFromDirectoryIsDeterministic(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{
+		"package.json":         `{"name":"example"}`,
+		"playwright.config.ts": "export default {}",
+		"tests/a.spec.ts":      "test('a', () => {})",
+		"tests/b.spec.ts":      "test('b', () => {})",
+	}
+
+	a := writeSourceTree(t, files)
+	b := writeSourceTree(t, files)
+
+	// Give the second tree different timestamps; they must not leak into
+	// the archive.
+	past := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+	for name := range files {
+		if err := os.Chtimes(filepath.Join(b, filepath.FromSlash(name)), past, past); err != nil {
+			t.Fatalf("chtimes: %v", err)
+		}
+	}
+
+	archiveA := buildArchiveBytes(t, a, archiveBuilderOptions{})
+	archiveB := buildArchiveBytes(t, b, archiveBuilderOptions{})
+
+	if !bytes.Equal(archiveA, archiveB) {
+		t.Error("archives of identical content should be byte-identical")
+	}
+
+	want := []string{"package.json", "playwright.config.ts", "tests/a.spec.ts", "tests/b.spec.ts"}
+	if got := archiveEntryNames(t, archiveA); !slices.Equal(got, want) {
+		t.Errorf("entries = %v, want %v", got, want)
+	}
+}
+
+func TestBuildArchiveFromDirectoryContentChangesArchive(t *testing.T) {
+	t.Parallel()
+
+	a := writeSourceTree(t, map[string]string{"tests/a.spec.ts": "one"})
+	b := writeSourceTree(t, map[string]string{"tests/a.spec.ts": "two"})
+
+	if bytes.Equal(
+		buildArchiveBytes(t, a, archiveBuilderOptions{}),
+		buildArchiveBytes(t, b, archiveBuilderOptions{}),
+	) {
+		t.Error("archives of different content should differ")
+	}
+}
+
+func TestBuildArchiveFromDirectoryFiltering(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{
+		".git/HEAD":                          "ref: refs/heads/main",
+		".gitignore":                         "*.log\n/dist/\n",
+		"debug.log":                          "noise",
+		"dist/bundle.js":                     "built",
+		"node_modules/@playwright/test/x.js": "dep",
+		"package.json":                       `{"name":"example"}`,
+		"packages/e2e/.gitignore":            "fixtures/large/\n!keep.log\n",
+		"packages/e2e/fixtures/large/a.bin":  "big",
+		"packages/e2e/fixtures/small.json":   "{}",
+		"packages/e2e/keep.log":              "kept",
+		"packages/e2e/node_modules/dep.js":   "dep",
+		"packages/e2e/tests/a.spec.ts":       "test",
+		"test-results/trace.zip":             "trace",
+		"tests/a.spec.ts":                    "test",
+		"tests/screenshots/a.png":            "png",
+	}
+	root := writeSourceTree(t, files)
+
+	tests := []struct {
+		name string
+		opts archiveBuilderOptions
+		want []string
+	}{
+		{
+			name: "default excludes only",
+			opts: archiveBuilderOptions{},
+			want: []string{
+				".gitignore",
+				"debug.log",
+				"dist/bundle.js",
+				"package.json",
+				"packages/e2e/.gitignore",
+				"packages/e2e/fixtures/large/a.bin",
+				"packages/e2e/fixtures/small.json",
+				"packages/e2e/keep.log",
+				"packages/e2e/tests/a.spec.ts",
+				"tests/a.spec.ts",
+				"tests/screenshots/a.png",
+			},
+		},
+		{
+			name: "gitignore is respected per directory",
+			opts: archiveBuilderOptions{RespectGitignore: true},
+			want: []string{
+				".gitignore",
+				"package.json",
+				"packages/e2e/.gitignore",
+				"packages/e2e/fixtures/small.json",
+				"packages/e2e/keep.log",
+				"packages/e2e/tests/a.spec.ts",
+				"tests/a.spec.ts",
+				"tests/screenshots/a.png",
+			},
+		},
+		{
+			name: "include selects directories and files",
+			opts: archiveBuilderOptions{
+				Include:          []string{"package.json", "/tests/"},
+				RespectGitignore: true,
+			},
+			want: []string{
+				"package.json",
+				"tests/a.spec.ts",
+				"tests/screenshots/a.png",
+			},
+		},
+		{
+			name: "exclude applies at any depth",
+			opts: archiveBuilderOptions{
+				Exclude:          []string{"*.png", "**/fixtures/"},
+				RespectGitignore: true,
+			},
+			want: []string{
+				".gitignore",
+				"package.json",
+				"packages/e2e/.gitignore",
+				"packages/e2e/keep.log",
+				"packages/e2e/tests/a.spec.ts",
+				"tests/a.spec.ts",
+			},
+		},
+		{
+			name: "negated exclude restores a default exclusion",
+			opts: archiveBuilderOptions{
+				Include: []string{"test-results/"},
+				Exclude: []string{"!test-results/"},
+			},
+			want: []string{
+				"test-results/trace.zip",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := archiveEntryNames(t, buildArchiveBytes(t, root, tt.opts))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("entries = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildArchiveFromDirectorySymlinks(t *testing.T) {
+	t.Parallel()
+
+	root := writeSourceTree(t, map[string]string{
+		"package.json":    `{"name":"example"}`,
+		"tests/a.spec.ts": "test",
+	})
+	if err := os.Symlink("tests/a.spec.ts", filepath.Join(root, "inside")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	if err := os.Symlink("../outside.ts", filepath.Join(root, "outside")); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+
+	p := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	if err := os.WriteFile(p, buildArchiveBytes(t, root, archiveBuilderOptions{}), 0644); err != nil {
+		t.Fatalf("write archive: %v", err)
+	}
+
+	// Symbolic links are packed as-is, so the regular archive inspection
+	// catches the one escaping the source directory.
+	err := (&PlaywrightCodeBundlePrebuiltArchiveAttribute{File: p}).InspectArchivePaths()
+	if err == nil {
+		t.Fatal("InspectArchivePaths should reject the escaping link")
+	}
+	if want := "outside -> ../outside.ts (the target escapes the archive root)"; !bytes.Contains([]byte(err.Error()), []byte(want)) {
+		t.Errorf("error %q does not mention %q", err, want)
+	}
+	if bytes.Contains([]byte(err.Error()), []byte("inside ->")) {
+		t.Errorf("error %q should not mention the contained link", err)
+	}
+}
+
+func TestCompileArchivePattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern string
+		path    string
+		isDir   bool
+		want    bool
+	}{
+		{"*.log", "debug.log", false, true},
+		{"*.log", "logs/debug.log", false, true},
+		{"/*.log", "logs/debug.log", false, false},
+		{"logs/*.log", "logs/debug.log", false, true},
+		{"logs/*.log", "a/logs/debug.log", false, false},
+		{"**/logs/*.log", "a/logs/debug.log", false, true},
+		{"logs/**", "logs/a/b/c.txt", false, true},
+		{"a/**/b", "a/b", false, true},
+		{"a/**/b", "a/x/y/b", false, true},
+		{"build/", "build", true, true},
+		{"build/", "build", false, false},
+		{"file?.txt", "file1.txt", false, true},
+		{"file?.txt", "file10.txt", false, false},
+		{"file[0-9].txt", "file7.txt", false, true},
+		{"file[!0-9].txt", "file7.txt", false, false},
+		{`\#notacomment`, "#notacomment", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			p, ok, err := compileArchivePattern("", tt.pattern)
+			if err != nil {
+				t.Fatalf("compileArchivePattern(%q) failed: %v", tt.pattern, err)
+			}
+			if !ok {
+				t.Fatalf("compileArchivePattern(%q) produced no pattern", tt.pattern)
+			}
+			if got := p.match(tt.path, tt.isDir); got != tt.want {
+				t.Errorf("%q matches %q = %v, want %v", tt.pattern, tt.path, got, tt.want)
+			}
+		})
+	}
+
+	for _, blank := range []string{"", "   ", "# comment"} {
+		if _, ok, _ := compileArchivePattern("", blank); ok {
+			t.Errorf("compileArchivePattern(%q) should produce no pattern", blank)
+		}
+	}
+}
@@ -189,14 +189,29 @@ func formatArchiveProblems(problems []string) error {
 		return nil
 	}
 
-	var b strings.Builder
-
 	noun := "entries"
 	if len(problems) == 1 {
 		noun = "entry"
 	}
 
-	fmt.Fprintf(&b, "the archive contains %d %s that cannot be safely extracted:\n", len(problems), noun)
+	return formatArchiveProblemList(
+		fmt.Sprintf("the archive contains %d %s that cannot be safely extracted:", len(problems), noun),
+		problems,
+		"every entry must extract within the archive root, and every symbolic "+
+			"or hard link must resolve to a file or directory that is also "+
+			"included in the archive; rebuild the archive without absolute or "+
+			"parent-escaping paths and with its symbolic links dereferenced",
+	)
+}
+
+// formatArchiveProblemList renders a header line, at most
+// maxReportedArchiveProblems of the problem lines and a closing line of
+// advice into a single error.
+func formatArchiveProblemList(header string, problems []string, advice string) error {
+	var b strings.Builder
+
+	b.WriteString(header)
+	b.WriteString("\n")
 
 	shown := problems
 	if len(shown) > maxReportedArchiveProblems {
@@ -212,12 +227,7 @@ func formatArchiveProblems(problems []string) error {
 		fmt.Fprintf(&b, "  ... and %d more\n", omitted)
 	}
 
-	b.WriteString(
-		"every entry must extract within the archive root, and every symbolic " +
-			"or hard link must resolve to a file or directory that is also " +
-			"included in the archive; rebuild the archive without absolute or " +
-			"parent-escaping paths and with its symbolic links dereferenced",
-	)
+	b.WriteString(advice)
 
 	return fmt.Errorf("%s", b.String())
 }
@@ -0,0 +1,262 @@
+package checkly
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// PlaywrightCodeBundleArchivePolicy describes what a code bundle archive may
+// contain. A zero limit means no limit.
+type PlaywrightCodeBundleArchivePolicy struct {
+	MaxCompressedBytes   int64
+	MaxUncompressedBytes int64
+	MaxFiles             int
+	DeniedPaths          []string
+	DetectSecrets        bool
+}
+
+func PlaywrightCodeBundleArchivePolicyFromList(
+	list []any,
+) (*PlaywrightCodeBundleArchivePolicy, error) {
+	if len(list) == 0 {
+		return nil, nil
+	}
+
+	// An empty block carries no values at all, not even the defaults.
+	if list[0] == nil {
+		return &PlaywrightCodeBundleArchivePolicy{DetectSecrets: true}, nil
+	}
+
+	m := list[0].(tfMap)
+
+	a := PlaywrightCodeBundleArchivePolicy{
+		MaxCompressedBytes:   int64(m["max_compressed_bytes"].(int)),
+		MaxUncompressedBytes: int64(m["max_uncompressed_bytes"].(int)),
+		MaxFiles:             m["max_files"].(int),
+		DeniedPaths:          stringsFromList(m["denied_paths"].([]any)),
+		DetectSecrets:        m["detect_secrets"].(bool),
+	}
+
+	return &a, nil
+}
+
+// maxSecretScanBytes bounds how much of each file the secret scan reads.
+// Credentials accidentally committed to a project live in small text files;
+// large files are almost always fixtures or build output.
+const maxSecretScanBytes = 1 << 20
+
+// archiveSecretPattern is a credential format recognized by the secret scan.
+type archiveSecretPattern struct {
+	description string
+	re          *regexp.Regexp
+}
+
+var archiveSecretPatterns = []archiveSecretPattern{
+	{"a private key", regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )?PRIVATE KEY-----`)},
+	{"an AWS access key ID", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"a GitHub token", regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)},
+	{"an npm access token", regexp.MustCompile(`\bnpm_[A-Za-z0-9]{36}\b`)},
+	{"an npm registry auth token", regexp.MustCompile(`_authToken\s*=\s*[^\s$"'][^\s]*`)},
+	{"a Slack token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{"a Stripe secret key", regexp.MustCompile(`\b(?:sk|rk)_live_[A-Za-z0-9]{24,}\b`)},
+	{"a Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+}
+
+// envFileRegexp matches dotenv files. Templates meant to be committed, such as
+// .env.example, are not matched.
+var envFileRegexp = regexp.MustCompile(`^\.env(?:\.[^.]+)*$`)
+
+var envFileTemplateSuffixes = []string{".example", ".sample", ".template", ".dist"}
+
+func isEnvFile(name string) bool {
+	base := path.Base(name)
+	if !envFileRegexp.MatchString(base) {
+		return false
+	}
+	for _, suffix := range envFileTemplateSuffixes {
+		if strings.HasSuffix(base, suffix) {
+			return false
+		}
+	}
+	return true
+}
+
+// InspectArchivePolicy checks the archive against policy and reports every
+// violation at once, in the same style as InspectArchivePaths.
+func (a *PlaywrightCodeBundlePrebuiltArchiveAttribute) InspectArchivePolicy(
+	policy *PlaywrightCodeBundleArchivePolicy,
+) error {
+	if policy == nil {
+		return nil
+	}
+
+	denied, err := compileArchivePatterns("", policy.DeniedPaths)
+	if err != nil {
+		return fmt.Errorf("invalid denied path pattern %w", err)
+	}
+
+	file, err := os.Open(a.File)
+	if err != nil {
+		return fmt.Errorf("failed to open archive file %q: %w", a.File, err)
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat archive file %q: %w", a.File, err)
+	}
+
+	gzr, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("failed to create gzip reader for %q: %w", a.File, err)
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+
+	var (
+		problems          []string
+		files             int
+		uncompressedBytes int64
+		deniedDirs        = make(map[string]bool)
+	)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive %q: %w", a.File, err)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := normalizeArchivePath(header.Name)
+
+		files++
+		uncompressedBytes += header.Size
+
+		if dir, ok := deniedArchiveDir(denied, name); ok {
+			// Report a denied directory once rather than every file in it.
+			if !deniedDirs[dir] {
+				deniedDirs[dir] = true
+				problems = append(problems, fmt.Sprintf("  - %s/ (the path is denied)", dir))
+			}
+			continue
+		}
+
+		if matchArchivePatterns(denied, name, false) {
+			problems = append(problems, fmt.Sprintf("  - %s (the path is denied)", name))
+			continue
+		}
+
+		if !policy.DetectSecrets {
+			continue
+		}
+
+		if isEnvFile(name) {
+			problems = append(problems, fmt.Sprintf("  - %s (an environment file, which usually holds secrets)", name))
+			continue
+		}
+
+		content, err := io.ReadAll(io.LimitReader(tr, maxSecretScanBytes))
+		if err != nil {
+			return fmt.Errorf("failed to read %q from archive %q: %w", header.Name, a.File, err)
+		}
+
+		if description := detectArchiveSecret(content); description != "" {
+			problems = append(problems, fmt.Sprintf("  - %s (the file appears to contain %s)", name, description))
+		}
+	}
+
+	// Limits are listed first, since they are usually the more fundamental
+	// problem.
+	var limits []string
+	if policy.MaxCompressedBytes > 0 && stat.Size() > policy.MaxCompressedBytes {
+		limits = append(limits, fmt.Sprintf(
+			"  - the compressed size of %d bytes exceeds max_compressed_bytes (%d)",
+			stat.Size(), policy.MaxCompressedBytes,
+		))
+	}
+	if policy.MaxUncompressedBytes > 0 && uncompressedBytes > policy.MaxUncompressedBytes {
+		limits = append(limits, fmt.Sprintf(
+			"  - the uncompressed size of %d bytes exceeds max_uncompressed_bytes (%d)",
+			uncompressedBytes, policy.MaxUncompressedBytes,
+		))
+	}
+	if policy.MaxFiles > 0 && files > policy.MaxFiles {
+		limits = append(limits, fmt.Sprintf(
+			"  - the archive holds %d files, more than max_files (%d)",
+			files, policy.MaxFiles,
+		))
+	}
+
+	return formatArchivePolicyProblems(append(limits, problems...))
+}
+
+// deniedArchiveDir returns the outermost directory containing name that is
+// matched by the denied patterns.
+func deniedArchiveDir(denied []archivePattern, name string) (string, bool) {
+	var dirs []string
+	for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+		dirs = append(dirs, dir)
+	}
+
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if matchArchivePatterns(denied, dirs[i], true) {
+			return dirs[i], true
+		}
+	}
+
+	return "", false
+}
+
+// detectArchiveSecret returns a description of the first credential found in
+// content, or an empty string if there is none. Binary content is skipped.
+func detectArchiveSecret(content []byte) string {
+	sniff := content
+	if len(sniff) > 8000 {
+		sniff = sniff[:8000]
+	}
+	if bytes.IndexByte(sniff, 0) >= 0 {
+		return ""
+	}
+
+	for _, p := range archiveSecretPatterns {
+		if p.re.Match(content) {
+			return p.description
+		}
+	}
+
+	return ""
+}
+
+// formatArchivePolicyProblems renders policy violations into a single error
+// the way formatArchiveProblems does for extraction problems.
+func formatArchivePolicyProblems(problems []string) error {
+	if len(problems) == 0 {
+		return nil
+	}
+
+	noun := "violations"
+	if len(problems) == 1 {
+		noun = "violation"
+	}
+
+	return formatArchiveProblemList(
+		fmt.Sprintf("the archive has %d archive policy %s:", len(problems), noun),
+		problems,
+		"remove the offending files from the archive, or adjust the "+
+			"archive_policy block of the code bundle if they are expected",
+	)
+}
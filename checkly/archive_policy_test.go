@@ -0,0 +1,144 @@
+package checkly
+
+import (
+	"archive/tar"
+	"strings"
+	"testing"
+)
+
+func TestInspectArchivePolicy(t *testing.T) {
+	t.Parallel()
+
+	// Built by concatenation so that the source itself does not trip secret
+	// scanners.
+	awsKey := "AKIA" + strings.Repeat("Q", 16)
+	privateKey := "-----BEGIN " + "RSA PRIVATE KEY-----\nMIIE...\n"
+
+	entries := []tarEntry{
+		{name: "package.json", content: []byte(`{"name":"test"}`)},
+		{name: "tests/a.spec.ts", content: []byte("test('a', () => {})")},
+		{name: "tests/fixtures/aws.json", content: []byte(`{"key":"` + awsKey + `"}`)},
+		{name: "certs/client.pem", content: []byte(privateKey)},
+		{name: ".env", content: []byte("TOKEN=abc")},
+		{name: ".env.example", content: []byte("TOKEN=")},
+		{name: ".npmrc", content: []byte("//registry.npmjs.org/:_authToken=${NPM_TOKEN}\n")},
+		{name: "node_modules/a/index.js", content: []byte("a")},
+		{name: "node_modules/b/index.js", content: []byte("b")},
+		{name: "traces/run.har", content: []byte("{}")},
+		{name: "bin/tool", content: []byte("\x00\x01" + awsKey)},
+		{name: "link", typeflag: tar.TypeSymlink, linkname: "package.json"},
+	}
+
+	tests := []struct {
+		name    string
+		policy  *PlaywrightCodeBundleArchivePolicy
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "no policy",
+			policy: nil,
+		},
+		{
+			name:   "empty policy",
+			policy: &PlaywrightCodeBundleArchivePolicy{},
+		},
+		{
+			name:    "secrets",
+			policy:  &PlaywrightCodeBundleArchivePolicy{DetectSecrets: true},
+			wantErr: true,
+			want: []string{
+				"the archive has 3 archive policy violations:",
+				"  - tests/fixtures/aws.json (the file appears to contain an AWS access key ID)",
+				"  - certs/client.pem (the file appears to contain a private key)",
+				"  - .env (an environment file, which usually holds secrets)",
+			},
+		},
+		{
+			name: "denied paths",
+			policy: &PlaywrightCodeBundleArchivePolicy{
+				DeniedPaths: []string{"node_modules/", "*.har", "certs/", "!certs/"},
+			},
+			wantErr: true,
+			want: []string{
+				"the archive has 2 archive policy violations:",
+				"  - node_modules/ (the path is denied)",
+				"  - traces/run.har (the path is denied)",
+			},
+		},
+		{
+			name: "limits",
+			policy: &PlaywrightCodeBundleArchivePolicy{
+				MaxCompressedBytes:   10,
+				MaxUncompressedBytes: 100,
+				MaxFiles:             5,
+			},
+			wantErr: true,
+			want: []string{
+				"the archive has 3 archive policy violations:",
+				"  - the compressed size of ",
+				"bytes exceeds max_compressed_bytes (10)",
+				"bytes exceeds max_uncompressed_bytes (100)",
+				"  - the archive holds 11 files, more than max_files (5)",
+			},
+		},
+		{
+			name: "limits not reached",
+			policy: &PlaywrightCodeBundleArchivePolicy{
+				MaxCompressedBytes:   1 << 20,
+				MaxUncompressedBytes: 1 << 20,
+				MaxFiles:             11,
+			},
+		},
+	}
+
+	archive := buildTarGz(t, entries)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := (&PlaywrightCodeBundlePrebuiltArchiveAttribute{File: archive}).InspectArchivePolicy(tt.policy)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("InspectArchivePolicy() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("InspectArchivePolicy() should fail")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestIsEnvFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{".env", true},
+		{".env.local", true},
+		{"packages/e2e/.env.production.local", true},
+		{".env.example", false},
+		{".env.sample", false},
+		{".envrc", false},
+		{"env.ts", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := isEnvFile(tt.name); got != tt.want {
+				t.Errorf("isEnvFile(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
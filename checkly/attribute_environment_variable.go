@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	checkly "github.com/checkly/checkly-go-sdk"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -26,9 +27,22 @@ func makeEnvironmentVariableAttributeSchema(options EnvironmentVariableAttribute
 					Required:    true,
 				},
 				"value": {
-					Description: "The value of the environment variable or secret.",
+					Description: "The value of the environment variable or secret. The value is stored in the Terraform state; for secrets, consider `value_wo` instead. Exactly one of `value` and `value_wo` must be set.",
 					Type:        schema.TypeString,
-					Required:    true,
+					Optional:    true,
+				},
+				writeOnlyValueAttributeName: {
+					Description: "The value of the environment variable or secret, which is sent to Checkly but never stored in the Terraform state or shown in plans. Requires Terraform 1.11 or later. Exactly one of `value` and `value_wo` must be set.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					WriteOnly:   true,
+					Sensitive:   true,
+				},
+				valueVersionAttributeName: {
+					Description:  "The version of `value_wo`, which must be set along with it. Since Terraform can't tell when a write-only value changes, change this to send a new `value_wo` to Checkly.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validateAtLeast(1),
 				},
 				"locked": {
 					Description: "If true, the value is not shown by default, but it can be accessed. (Default `false`).",
@@ -108,19 +122,30 @@ func environmentVariablesFromList(s []any) []checkly.EnvironmentVariable {
 	return res
 }
 
-func listFromEnvironmentVariables(ev []checkly.EnvironmentVariable) []tfMap {
+// listFromEnvironmentVariables returns the environment_variable blocks for
+// ev. The value_version of each variable is carried over from prior, the
+// blocks in the state, and the value of variables with a value_version is left
+// out, since it is write-only.
+func listFromEnvironmentVariables(ev []checkly.EnvironmentVariable, prior []any) []tfMap {
 	if ev == nil {
 		return []tfMap{}
 	}
 
+	versions := writeOnlyValueVersions(prior)
+
 	res := []tfMap{}
 	for _, v := range ev {
-		res = append(res, tfMap{
+		tm := tfMap{
 			"key":    v.Key,
 			"value":  v.Value,
 			"locked": v.Locked,
 			"secret": v.Secret,
-		})
+		}
+		if version, ok := versions[v.Key]; ok {
+			tm["value"] = ""
+			tm[valueVersionAttributeName] = version
+		}
+		res = append(res, tm)
 	}
 
 	return res
@@ -134,6 +159,20 @@ func environmentVariablesFromResourceData(d *schema.ResourceData) ([]checkly.Env
 
 	vars := environmentVariablesFromList(val.([]any))
 
+	// Write-only values are only available in the configuration.
+	for i, item := range val.([]any) {
+		if version, _ := item.(tfMap)[valueVersionAttributeName].(int); version == 0 {
+			continue
+		}
+
+		path := cty.GetAttrPath(environmentVariableAttributeName).IndexInt(i).GetAttr(writeOnlyValueAttributeName)
+		value, ok := writeOnlyValueFromConfig(d, path)
+		if !ok {
+			return nil, writeOnlyValueError(vars[i].Key)
+		}
+		vars[i].Value = value
+	}
+
 	return vars, nil
 }
 
@@ -182,7 +221,8 @@ func updateCompatEnvironmentVariablesResourceData(d *schema.ResourceData, evs []
 	// Otherwise, always update new-style variables and clear the old-style
 	// variables.
 	default:
-		err := d.Set(environmentVariableAttributeName, listFromEnvironmentVariables(evs))
+		prior, _ := d.Get(environmentVariableAttributeName).([]any)
+		err := d.Set(environmentVariableAttributeName, listFromEnvironmentVariables(evs, prior))
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,31 @@
+package checkly
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const tagsAllAttributeName = "tags_all"
+
+var tagsAllAttributeSchema = &schema.Schema{
+	Type:     schema.TypeSet,
+	Computed: true,
+	Elem: &schema.Schema{
+		Type: schema.TypeString,
+	},
+	Description: "All tags of the check, including the `default_tags` of the provider.",
+}
+
+// TagsAllCustomizeDiff plans tags_all as the tags of the resource merged with
+// the default tags of the provider, so that a change to either shows up in
+// the plan.
+func TagsAllCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta any) error {
+	if !diff.NewValueKnown("tags") {
+		return diff.SetNewComputed(tagsAllAttributeName)
+	}
+
+	tags := stringsFromSet(diff.Get("tags").(*schema.Set))
+
+	return diff.SetNew(tagsAllAttributeName, providerDefaultsFromMeta(meta).mergeTags(tags))
+}
@@ -0,0 +1,293 @@
+package checkly
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/pbkdf2"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"slices"
+	"time"
+)
+
+// clientCertificateInfo describes the leaf of a client certificate.
+type clientCertificateInfo struct {
+	NotAfter    time.Time
+	Subject     string
+	SANs        []string
+	Fingerprint string
+}
+
+// parseCertificates returns the certificates in a PEM bundle. Every block of
+// the bundle must be a certificate.
+func parseCertificates(bundle string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+
+	rest := []byte(bundle)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block %q, only CERTIFICATE blocks are allowed", block.Type)
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate %d: %w", len(certs)+1, err)
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+
+	return certs, nil
+}
+
+// parsePrivateKey returns the private key in a PEM block, decrypting it with
+// passphrase if it is encrypted. Both PKCS #8 keys encrypted with PBES2 and
+// legacy keys with an encryption header are supported.
+func parsePrivateKey(key, passphrase string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return nil, errors.New("no PEM encoded private key found")
+	}
+
+	der := block.Bytes
+
+	// Legacy encryption, with a Proc-Type header, is deprecated for being
+	// weak, but keys encrypted this way are still around.
+	if x509.IsEncryptedPEMBlock(block) {
+		if passphrase == "" {
+			return nil, errors.New("the private key is encrypted, but no passphrase is set")
+		}
+		var err error
+		der, err = x509.DecryptPEMBlock(block, []byte(passphrase))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt the private key, check the passphrase: %w", err)
+		}
+	}
+
+	var parsed any
+	var err error
+	switch block.Type {
+	case "ENCRYPTED PRIVATE KEY":
+		if passphrase == "" {
+			return nil, errors.New("the private key is encrypted, but no passphrase is set")
+		}
+		der, err = decryptPKCS8(der, passphrase)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err = x509.ParsePKCS8PrivateKey(der)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(der)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(der)
+	case "EC PRIVATE KEY":
+		parsed, err = x509.ParseECPrivateKey(der)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q for a private key", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the private key: %w", err)
+	}
+
+	signer, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", parsed)
+	}
+
+	return signer, nil
+}
+
+var (
+	oidPBES2  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA224 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 8}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+
+	oidAES128CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+	oidDESEDE3CBC = asn1.ObjectIdentifier{1, 2, 840, 113549, 3, 7}
+)
+
+// encryptedPrivateKeyInfo is defined in RFC 5208, and the PBES2 parameters in
+// RFC 8018.
+type encryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// decryptPKCS8 decrypts an encrypted PKCS #8 key, as written by
+// `openssl pkcs8 -topk8` and `openssl genpkey -aes256`, with passphrase.
+func decryptPKCS8(der []byte, passphrase string) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse the encrypted private key: %w", err)
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("unsupported private key encryption %s, only PBES2 is supported", info.Algorithm.Algorithm)
+	}
+
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("failed to parse the PBES2 parameters of the private key: %w", err)
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("unsupported private key derivation function %s, only PBKDF2 is supported", params.KeyDerivationFunc.Algorithm)
+	}
+
+	var kdf pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+		return nil, fmt.Errorf("failed to parse the PBKDF2 parameters of the private key: %w", err)
+	}
+
+	var prf func() hash.Hash
+	switch alg := kdf.PRF.Algorithm; {
+	case len(alg) == 0, alg.Equal(oidHMACWithSHA1):
+		prf = sha1.New
+	case alg.Equal(oidHMACWithSHA224):
+		prf = sha256.New224
+	case alg.Equal(oidHMACWithSHA256):
+		prf = sha256.New
+	case alg.Equal(oidHMACWithSHA384):
+		prf = sha512.New384
+	case alg.Equal(oidHMACWithSHA512):
+		prf = sha512.New
+	default:
+		return nil, fmt.Errorf("unsupported PBKDF2 pseudorandom function %s", alg)
+	}
+
+	var keyLength int
+	var newCipher func([]byte) (cipher.Block, error)
+	switch alg := params.EncryptionScheme.Algorithm; {
+	case alg.Equal(oidAES128CBC):
+		keyLength, newCipher = 16, aes.NewCipher
+	case alg.Equal(oidAES192CBC):
+		keyLength, newCipher = 24, aes.NewCipher
+	case alg.Equal(oidAES256CBC):
+		keyLength, newCipher = 32, aes.NewCipher
+	case alg.Equal(oidDESEDE3CBC):
+		keyLength, newCipher = 24, des.NewTripleDESCipher
+	default:
+		return nil, fmt.Errorf("unsupported private key cipher %s", alg)
+	}
+
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, fmt.Errorf("failed to parse the IV of the private key: %w", err)
+	}
+
+	key, err := pbkdf2.Key(prf, passphrase, kdf.Salt, kdf.IterationCount, keyLength)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive the private key encryption key: %w", err)
+	}
+
+	block, err := newCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	data := info.EncryptedData
+	if len(iv) != block.BlockSize() || len(data) == 0 || len(data)%block.BlockSize() != 0 {
+		return nil, errors.New("the encrypted private key is malformed")
+	}
+
+	plain := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, data)
+
+	// A wrong passphrase shows as invalid padding, in all but rare cases,
+	// which parsing the key catches instead.
+	padding := int(plain[len(plain)-1])
+	if padding == 0 || padding > block.BlockSize() || slices.ContainsFunc(plain[len(plain)-padding:], func(b byte) bool { return int(b) != padding }) {
+		return nil, errors.New("failed to decrypt the private key, check the passphrase")
+	}
+
+	return plain[:len(plain)-padding], nil
+}
+
+// keyMatchesCertificate reports whether key is the private key of cert.
+func keyMatchesCertificate(cert *x509.Certificate, key crypto.Signer) bool {
+	pub, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool })
+	return ok && pub.Equal(cert.PublicKey)
+}
+
+// inspectClientCertificate parses a client certificate, its private key and
+// trusted CA bundle, and checks that they belong together. The trusted CA
+// bundle is optional.
+func inspectClientCertificate(certificate, privateKey, passphrase, trustedCA string) (clientCertificateInfo, error) {
+	certs, err := parseCertificates(certificate)
+	if err != nil {
+		return clientCertificateInfo{}, fmt.Errorf("invalid certificate: %w", err)
+	}
+	leaf := certs[0]
+
+	key, err := parsePrivateKey(privateKey, passphrase)
+	if err != nil {
+		return clientCertificateInfo{}, fmt.Errorf("invalid private_key: %w", err)
+	}
+	if !keyMatchesCertificate(leaf, key) {
+		return clientCertificateInfo{}, fmt.Errorf("private_key does not belong to the certificate for %q", leaf.Subject)
+	}
+
+	if trustedCA != "" {
+		if _, err := parseCertificates(trustedCA); err != nil {
+			return clientCertificateInfo{}, fmt.Errorf("invalid trusted_ca: %w", err)
+		}
+	}
+
+	return describeCertificate(leaf), nil
+}
+
+func describeCertificate(cert *x509.Certificate) clientCertificateInfo {
+	var sans []string
+	sans = append(sans, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	sans = append(sans, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+
+	fingerprint := sha256.Sum256(cert.Raw)
+
+	return clientCertificateInfo{
+		NotAfter:    cert.NotAfter.UTC(),
+		Subject:     cert.Subject.String(),
+		SANs:        sans,
+		Fingerprint: hex.EncodeToString(fingerprint[:]),
+	}
+}
@@ -0,0 +1,117 @@
+package checkly
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+var alertChannelTypes = []string{
+	checkly.AlertTypeEmail,
+	checkly.AlertTypeSlack,
+	checkly.AlertTypeSlackApp,
+	checkly.AlertTypeWebhook,
+	checkly.AlertTypeSMS,
+	checkly.AlertTypeCall,
+	checkly.AlertTypeOpsgenie,
+	checkly.AlertTypePagerduty,
+}
+
+func dataSourceAlertChannel() *schema.Resource {
+	s := dataSourceSchemaFromResourceSchema(resourceAlertChannel().Schema)
+
+	s["name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  "The name of the alert channel to look up: the name of SMS, phone call, webhook and Opsgenie channels, the address of email channels, the channel of Slack channels and the service name of PagerDuty channels. At least one of `name` and `type` must be set, and exactly one alert channel must match them.",
+		AtLeastOneOf: []string{"name", "type"},
+	}
+	s["type"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		Description:  fmt.Sprintf("The type of the alert channel to look up. Possible values are %s.", "`"+strings.Join(alertChannelTypes, "`, `")+"`"),
+		ValidateFunc: validateOneOf(alertChannelTypes),
+		AtLeastOneOf: []string{"name", "type"},
+	}
+
+	return &schema.Resource{
+		ReadContext: withAPIErrors(dataSourceAlertChannelRead),
+		Description: "Looks up an existing alert channel by name and type, " +
+			"such as one owned by another team, and exposes the same " +
+			"attributes as the `checkly_alert_channel` resource.",
+		Schema: s,
+	}
+}
+
+// alertChannelSummary is an alert channel as listed by the API.
+type alertChannelSummary struct {
+	ID     int64          `json:"id"`
+	Type   string         `json:"type"`
+	Config map[string]any `json:"config"`
+}
+
+// name returns what identifies the channel to a person, which depends on its
+// type.
+func (ac alertChannelSummary) name() string {
+	key := "name"
+	switch ac.Type {
+	case checkly.AlertTypeEmail:
+		key = "address"
+	case checkly.AlertTypeSlack:
+		key = "channel"
+	case checkly.AlertTypePagerduty:
+		key = "serviceName"
+	}
+
+	name, _ := ac.Config[key].(string)
+	return name
+}
+
+func dataSourceAlertChannelRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
+	name := d.Get("name").(string)
+	typ := d.Get("type").(string)
+
+	api, err := apiClientFromMeta(client)
+	if err != nil {
+		return err
+	}
+
+	channels, err := listAPIPages[alertChannelSummary](ctx, api, "/v1/alert-channels", nil)
+	if err != nil {
+		return fmt.Errorf("failed to list alert channels: %w", err)
+	}
+
+	var criteria []string
+	if typ != "" {
+		criteria = append(criteria, fmt.Sprintf("of type %s", typ))
+	}
+	if name != "" {
+		criteria = append(criteria, fmt.Sprintf("named %q", name))
+	}
+
+	found, err := findOne(channels, "alert channel", strings.Join(criteria, " "),
+		func(ac alertChannelSummary) string { return strconv.FormatInt(ac.ID, 10) },
+		func(ac alertChannelSummary) bool {
+			return (typ == "" || ac.Type == typ) && (name == "" || ac.name() == name)
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	ac, err := client.(checkly.Client).GetAlertChannel(ctx, found.ID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve alert channel %d: %w", found.ID, err)
+	}
+
+	d.SetId(strconv.FormatInt(found.ID, 10))
+	d.Set("type", found.Type)
+
+	return resourceDataFromAlertChannel(ac, d)
+}
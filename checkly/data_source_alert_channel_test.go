@@ -0,0 +1,36 @@
+package checkly
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAlertChannelDataSource(t *testing.T) {
+	name := fmt.Sprintf("tf-test-webhook-%d", acctest.RandInt())
+
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: fmt.Sprintf(`
+				resource "checkly_alert_channel" "test" {
+					webhook {
+						name   = %q
+						method = "POST"
+						url    = "https://example.com/webhook"
+					}
+				}
+
+				data "checkly_alert_channel" "test" {
+					type = "WEBHOOK"
+					name = checkly_alert_channel.test.webhook[0].name
+				}
+			`, name),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrPair("data.checkly_alert_channel.test", "id", "checkly_alert_channel.test", "id"),
+				resource.TestCheckResourceAttr("data.checkly_alert_channel.test", "webhook.0.url", "https://example.com/webhook"),
+			),
+		},
+	})
+}
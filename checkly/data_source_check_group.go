@@ -0,0 +1,67 @@
+package checkly
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+func dataSourceCheckGroup() *schema.Resource {
+	s := dataSourceSchemaFromResourceSchema(resourceCheckGroup().Schema)
+
+	s["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "The name of the check group to look up. Exactly one check group must have this name.",
+	}
+
+	return &schema.Resource{
+		ReadContext: withAPIErrors(dataSourceCheckGroupRead),
+		Description: "Looks up an existing check group by name, such as one " +
+			"managed in another workspace, and exposes the same attributes " +
+			"as the `checkly_check_group` resource.",
+		Schema: s,
+	}
+}
+
+// checkGroupSummary is a check group as listed by the API.
+type checkGroupSummary struct {
+	ID               int64    `json:"id"`
+	Name             string   `json:"name"`
+	PrivateLocations []string `json:"privateLocations"`
+}
+
+func dataSourceCheckGroupRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
+	name := d.Get("name").(string)
+
+	api, err := apiClientFromMeta(client)
+	if err != nil {
+		return err
+	}
+
+	groups, err := listAPIPages[checkGroupSummary](ctx, api, "/v1/check-groups", nil)
+	if err != nil {
+		return fmt.Errorf("failed to list check groups: %w", err)
+	}
+
+	found, err := findOne(groups, "check group", fmt.Sprintf("named %q", name),
+		func(g checkGroupSummary) string { return strconv.FormatInt(g.ID, 10) },
+		func(g checkGroupSummary) bool { return g.Name == name },
+	)
+	if err != nil {
+		return err
+	}
+
+	group, err := client.(checkly.Client).GetGroup(ctx, found.ID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve check group %d: %w", found.ID, err)
+	}
+
+	d.SetId(strconv.FormatInt(found.ID, 10))
+
+	return resourceDataFromCheckGroup(group, d)
+}
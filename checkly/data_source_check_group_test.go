@@ -0,0 +1,36 @@
+package checkly
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCheckGroupDataSource(t *testing.T) {
+	name := fmt.Sprintf("tf-test-group-%d", acctest.RandInt())
+
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: fmt.Sprintf(`
+				resource "checkly_check_group" "test" {
+					name        = %q
+					activated   = true
+					concurrency = 3
+					locations   = ["eu-west-1"]
+					tags        = ["data-source-test"]
+				}
+
+				data "checkly_check_group" "test" {
+					name = checkly_check_group.test.name
+				}
+			`, name),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrPair("data.checkly_check_group.test", "id", "checkly_check_group.test", "id"),
+				resource.TestCheckResourceAttr("data.checkly_check_group.test", "concurrency", "3"),
+				resource.TestCheckResourceAttr("data.checkly_check_group.test", "tags.#", "1"),
+			),
+		},
+	})
+}
@@ -0,0 +1,260 @@
+package checkly
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceChecks() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: withAPIErrors(dataSourceChecksRead),
+		Description: "Lists the checks and monitors of the account, including " +
+			"those managed in other workspaces or by the Checkly CLI, " +
+			"optionally filtered by name, type, tags, group and status.",
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA-256 checksum of the IDs of the matching checks.",
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only list checks whose name matches this regular expression.",
+				ValidateFunc: validateRegexp(),
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list checks of this type, for example `API`, `BROWSER`, `MULTI_STEP`, `HEARTBEAT` or `URL`.",
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Only list checks with these tags. By default, a check needs to have at least one of them; see `use_tags_and_operator`.",
+			},
+			"use_tags_and_operator": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Only list checks that have all of `tags`, instead of at least one of them. (Default `false`).",
+			},
+			"group_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only list checks in the check group with this ID.",
+			},
+			"activated": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only list checks that are activated, if `true`, or deactivated, if `false`.",
+			},
+			"muted": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only list checks that are muted, if `true`, or not muted, if `false`.",
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The IDs of the matching checks, in the same order as `checks`.",
+			},
+			"checks": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching checks, sorted by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the check.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the check.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the check.",
+						},
+						"tags": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "The tags of the check.",
+						},
+						"group_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the check group the check is part of, or `0`.",
+						},
+						"activated": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the check is activated.",
+						},
+						"muted": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the check is muted.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// checkSummary is a check as listed by the API. Only the fields the provider
+// uses are decoded.
+type checkSummary struct {
+	ID               string   `json:"id"`
+	Name             string   `json:"name"`
+	CheckType        string   `json:"checkType"`
+	Tags             []string `json:"tags"`
+	GroupID          int64    `json:"groupId"`
+	Activated        bool     `json:"activated"`
+	Muted            bool     `json:"muted"`
+	PrivateLocations []string `json:"privateLocations"`
+}
+
+// checksFilter selects checks. Zero values match every check.
+type checksFilter struct {
+	NameRegexp *regexp.Regexp
+	Type       string
+	Tags       []string
+	AllTags    bool
+	GroupID    int64
+	Activated  *bool
+	Muted      *bool
+}
+
+func checksFilterFromResourceData(d *schema.ResourceData) (checksFilter, error) {
+	filter := checksFilter{
+		Type:    d.Get("type").(string),
+		Tags:    stringsFromSet(d.Get("tags").(*schema.Set)),
+		AllTags: d.Get("use_tags_and_operator").(bool),
+		GroupID: int64(d.Get("group_id").(int)),
+	}
+
+	if v := d.Get("name_regex").(string); v != "" {
+		re, err := regexp.Compile(v)
+		if err != nil {
+			return checksFilter{}, fmt.Errorf("invalid name_regex %q: %w", v, err)
+		}
+		filter.NameRegexp = re
+	}
+
+	// Optional booleans cannot tell false from unset, but the configuration
+	// can.
+	if config := d.GetRawConfig(); !config.IsNull() {
+		if v := config.GetAttr("activated"); !v.IsNull() {
+			activated := v.True()
+			filter.Activated = &activated
+		}
+		if v := config.GetAttr("muted"); !v.IsNull() {
+			muted := v.True()
+			filter.Muted = &muted
+		}
+	}
+
+	return filter, nil
+}
+
+func (f checksFilter) matches(c checkSummary) bool {
+	switch {
+	case f.NameRegexp != nil && !f.NameRegexp.MatchString(c.Name):
+		return false
+	case f.Type != "" && !strings.EqualFold(f.Type, c.CheckType):
+		return false
+	case f.GroupID != 0 && f.GroupID != c.GroupID:
+		return false
+	case f.Activated != nil && *f.Activated != c.Activated:
+		return false
+	case f.Muted != nil && *f.Muted != c.Muted:
+		return false
+	}
+
+	if len(f.Tags) == 0 {
+		return true
+	}
+
+	hasTag := func(tag string) bool {
+		return slices.Contains(c.Tags, tag)
+	}
+	if f.AllTags {
+		return !slices.ContainsFunc(f.Tags, func(tag string) bool { return !hasTag(tag) })
+	}
+	return slices.ContainsFunc(f.Tags, hasTag)
+}
+
+func dataSourceChecksRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
+	filter, err := checksFilterFromResourceData(d)
+	if err != nil {
+		return err
+	}
+
+	api, err := apiClientFromMeta(client)
+	if err != nil {
+		return err
+	}
+
+	checks, err := listAPIPages[checkSummary](ctx, api, "/v1/checks", nil)
+	if err != nil {
+		return fmt.Errorf("failed to list checks: %w", err)
+	}
+
+	checks = slices.DeleteFunc(checks, func(c checkSummary) bool {
+		return !filter.matches(c)
+	})
+	slices.SortFunc(checks, func(a, b checkSummary) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+	})
+
+	return dataSourceFromChecks(checks, d)
+}
+
+func dataSourceFromChecks(checks []checkSummary, d *schema.ResourceData) error {
+	ids := make([]string, len(checks))
+	list := make([]tfMap, len(checks))
+	for i, c := range checks {
+		ids[i] = c.ID
+		list[i] = tfMap{
+			"id":        c.ID,
+			"name":      c.Name,
+			"type":      c.CheckType,
+			"tags":      c.Tags,
+			"group_id":  int(c.GroupID),
+			"activated": c.Activated,
+			"muted":     c.Muted,
+		}
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return fmt.Errorf("error setting ids: %w", err)
+	}
+	if err := d.Set("checks", list); err != nil {
+		return fmt.Errorf("error setting checks: %w", err)
+	}
+
+	d.SetId(checksumSha256(strings.NewReader(strings.Join(ids, "\n"))))
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package checkly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccChecksByTag(t *testing.T) {
+	config := `
+resource "checkly_check" "test" {
+  name      = "checkly_checks data source test"
+  type      = "API"
+  activated = true
+  frequency = 60
+  locations = ["eu-central-1"]
+  tags      = ["data-source-test", "api"]
+
+  request {
+    url = "https://api.checklyhq.com/public-stats"
+  }
+}
+
+data "checkly_checks" "test" {
+  tags       = ["data-source-test"]
+  type       = "API"
+  name_regex = "^checkly_checks data source"
+
+  depends_on = [checkly_check.test]
+}`
+
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: config,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("data.checkly_checks.test", "checks.#", "1"),
+				resource.TestCheckResourceAttrPair("data.checkly_checks.test", "ids.0", "checkly_check.test", "id"),
+				resource.TestCheckResourceAttr("data.checkly_checks.test", "checks.0.type", "API"),
+			),
+		},
+	})
+}
+
+func TestChecksFilterMatches(t *testing.T) {
+	t.Parallel()
+
+	yes, no := true, false
+
+	check := checkSummary{
+		ID:        "1",
+		Name:      "Payments API",
+		CheckType: "API",
+		Tags:      []string{"team:payments", "api"},
+		GroupID:   42,
+		Activated: true,
+	}
+
+	tests := []struct {
+		name   string
+		filter checksFilter
+		want   bool
+	}{
+		{"no filter", checksFilter{}, true},
+		{"name", checksFilter{NameRegexp: regexp.MustCompile("^Payments")}, true},
+		{"other name", checksFilter{NameRegexp: regexp.MustCompile("^Search")}, false},
+		{"type", checksFilter{Type: "api"}, true},
+		{"other type", checksFilter{Type: "BROWSER"}, false},
+		{"group", checksFilter{GroupID: 42}, true},
+		{"other group", checksFilter{GroupID: 7}, false},
+		{"activated", checksFilter{Activated: &yes}, true},
+		{"deactivated", checksFilter{Activated: &no}, false},
+		{"not muted", checksFilter{Muted: &no}, true},
+		{"any tag", checksFilter{Tags: []string{"browser", "api"}}, true},
+		{"no tag", checksFilter{Tags: []string{"browser"}}, false},
+		{"all tags", checksFilter{Tags: []string{"team:payments", "api"}, AllTags: true}, true},
+		{"not all tags", checksFilter{Tags: []string{"team:payments", "browser"}, AllTags: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.filter.matches(check); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDataSourceChecksRead(t *testing.T) {
+	t.Parallel()
+
+	// 150 checks take two pages.
+	var checks []checkSummary
+	for i := range 150 {
+		checks = append(checks, checkSummary{
+			ID:        strconv.Itoa(i),
+			Name:      fmt.Sprintf("check %03d", i),
+			CheckType: "API",
+		})
+	}
+
+	api := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/checks" || r.URL.Query().Get("limit") != "100" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		start := min((page-1)*apiPageSize, len(checks))
+		end := min(start+apiPageSize, len(checks))
+		json.NewEncoder(w).Encode(checks[start:end])
+	})
+
+	meta := &providerMeta{
+		api: api,
+	}
+
+	d := dataSourceChecks().TestResourceData()
+	d.Set("name_regex", "^check 1")
+
+	if err := dataSourceChecksRead(context.Background(), d, meta); err != nil {
+		t.Fatalf("dataSourceChecksRead failed: %v", err)
+	}
+
+	var want []any
+	for i := 100; i < 150; i++ {
+		want = append(want, strconv.Itoa(i))
+	}
+	if diff := cmp.Diff(want, d.Get("ids").([]any)); diff != "" {
+		t.Errorf("ids mismatch (-want +got):\n%s", diff)
+	}
+	if got := d.Get("checks.0.name"); got != "check 100" {
+		t.Errorf("checks.0.name = %v, want %q", got, "check 100")
+	}
+	if d.Id() == "" {
+		t.Error("ID is not set")
+	}
+}
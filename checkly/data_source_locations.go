@@ -0,0 +1,158 @@
+package checkly
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceLocations() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: withAPIErrors(dataSourceLocationsRead),
+		Description: "Lists the public locations that checks, monitors and " +
+			"check groups can run in.",
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA-256 checksum of the IDs of the listed locations.",
+			},
+			"continent": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list locations on this continent, for example `Europe` or `North America`.",
+			},
+			"ipv6_support": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only list locations that support IPv6, if `true`, or that do not, if `false`.",
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The IDs of the listed locations, in the same order as `locations`.",
+			},
+			"locations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The listed locations, sorted by ID.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the location, as used in `locations`, such as `eu-central-1`.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the location, such as `Frankfurt`.",
+						},
+						"region": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The region of the location, such as `eu-central`.",
+						},
+						"continent": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The continent of the location, such as `Europe`.",
+						},
+						"ipv6_support": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether checks can reach IPv6 targets from the location. It is `false` for every location if the static IPv6 addresses can't be listed.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// locationsFilter selects locations. Zero values match every location.
+type locationsFilter struct {
+	Continent   string
+	IPv6Support *bool
+}
+
+func locationsFilterFromResourceData(d *schema.ResourceData) locationsFilter {
+	filter := locationsFilter{
+		Continent: d.Get("continent").(string),
+	}
+
+	// Optional booleans cannot tell false from unset, but the configuration
+	// can.
+	if config := d.GetRawConfig(); !config.IsNull() {
+		if v := config.GetAttr("ipv6_support"); !v.IsNull() {
+			ipv6 := v.True()
+			filter.IPv6Support = &ipv6
+		}
+	}
+
+	return filter
+}
+
+func (f locationsFilter) matches(l locationInfo) bool {
+	switch {
+	case f.Continent != "" && !strings.EqualFold(f.Continent, l.continent()):
+		return false
+	case f.IPv6Support != nil && *f.IPv6Support != l.IPv6Support:
+		return false
+	}
+	return true
+}
+
+func dataSourceLocationsRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
+	m := client.(*providerMeta)
+
+	locations, err := m.locations.load(ctx, m.api)
+	if err != nil {
+		return err
+	}
+
+	filter := locationsFilterFromResourceData(d)
+
+	// The catalog is shared, so it is filtered and sorted in a copy.
+	locations = slices.DeleteFunc(slices.Clone(locations), func(l locationInfo) bool {
+		return !filter.matches(l)
+	})
+	slices.SortFunc(locations, func(a, b locationInfo) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return dataSourceFromLocations(locations, d)
+}
+
+func dataSourceFromLocations(locations []locationInfo, d *schema.ResourceData) error {
+	ids := make([]string, len(locations))
+	list := make([]tfMap, len(locations))
+	for i, l := range locations {
+		ids[i] = l.ID
+		list[i] = tfMap{
+			"id":           l.ID,
+			"name":         l.Name,
+			"region":       l.region(),
+			"continent":    l.continent(),
+			"ipv6_support": l.IPv6Support,
+		}
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return fmt.Errorf("error setting ids: %w", err)
+	}
+	if err := d.Set("locations", list); err != nil {
+		return fmt.Errorf("error setting locations: %w", err)
+	}
+
+	d.SetId(checksumSha256(strings.NewReader(strings.Join(ids, "\n"))))
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package checkly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccLocations(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: `data "checkly_locations" "test" {}`,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckTypeSetElemAttr("data.checkly_locations.test", "ids.*", "eu-central-1"),
+			),
+		},
+	})
+}
+
+func TestDataSourceLocationsRead(t *testing.T) {
+	t.Parallel()
+
+	api := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/locations":
+			json.NewEncoder(w).Encode([]map[string]string{
+				{"region": "us-east-1", "name": "N. Virginia"},
+				{"region": "eu-west-1", "name": "Ireland"},
+				{"region": "eu-central-1", "name": "Frankfurt"},
+			})
+		case "/v1/static-ipv6s-by-region":
+			json.NewEncoder(w).Encode(map[string][]string{
+				"eu-central-1": {"2a05:d014::/56"},
+			})
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	meta := &providerMeta{
+		api: api,
+	}
+
+	d := dataSourceLocations().TestResourceData()
+	d.Set("continent", "europe")
+
+	if err := dataSourceLocationsRead(context.Background(), d, meta); err != nil {
+		t.Fatalf("dataSourceLocationsRead failed: %v", err)
+	}
+
+	if diff := cmp.Diff([]any{"eu-central-1", "eu-west-1"}, d.Get("ids").([]any)); diff != "" {
+		t.Errorf("ids mismatch (-want +got):\n%s", diff)
+	}
+	if got := d.Get("locations.0.name"); got != "Frankfurt" {
+		t.Errorf("locations.0.name = %v, want %q", got, "Frankfurt")
+	}
+	if got := d.Get("locations.0.ipv6_support"); got != true {
+		t.Errorf("locations.0.ipv6_support = %v, want true", got)
+	}
+	if got := d.Get("locations.1.region"); got != "eu-west" {
+		t.Errorf("locations.1.region = %v, want %q", got, "eu-west")
+	}
+}
@@ -0,0 +1,79 @@
+package checkly
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceSchemaFromResourceSchema returns a copy of the schema of a
+// resource in which every attribute is computed, so that a data source can
+// expose the same attributes and fill them with the same resourceDataFrom*
+// function. The attributes a data source is looked up by are made optional
+// or required again by the caller.
+func dataSourceSchemaFromResourceSchema(rs map[string]*schema.Schema) map[string]*schema.Schema {
+	ds := make(map[string]*schema.Schema, len(rs))
+	for k, v := range rs {
+		ds[k] = dataSourceAttributeFromResourceAttribute(v)
+	}
+	return ds
+}
+
+// defaultDescriptionRegexp matches the "(Default `x`)" that ends the
+// description of an attribute with a default, which a data source has no use
+// for.
+var defaultDescriptionRegexp = regexp.MustCompile(" ?\\(Default `[^`]*`\\)\\.?$")
+
+func dataSourceAttributeFromResourceAttribute(rs *schema.Schema) *schema.Schema {
+	ds := &schema.Schema{
+		Type:        rs.Type,
+		Computed:    true,
+		Sensitive:   rs.Sensitive,
+		Description: defaultDescriptionRegexp.ReplaceAllString(rs.Description, ""),
+		Set:         rs.Set,
+		ConfigMode:  rs.ConfigMode,
+	}
+
+	switch elem := rs.Elem.(type) {
+	case *schema.Resource:
+		ds.Elem = &schema.Resource{
+			Schema: dataSourceSchemaFromResourceSchema(elem.Schema),
+		}
+	case *schema.Schema:
+		ds.Elem = &schema.Schema{
+			Type: elem.Type,
+		}
+	}
+
+	return ds
+}
+
+// findOne returns the single item that match selects. kind names what is
+// looked up, and criteria describes the selection, for error messages such as
+// `no check group named "Payments" was found`.
+func findOne[T any](items []T, kind, criteria string, id func(T) string, match func(T) bool) (T, error) {
+	var found []T
+	for _, item := range items {
+		if match(item) {
+			found = append(found, item)
+		}
+	}
+
+	switch len(found) {
+	case 1:
+		return found[0], nil
+	case 0:
+		var zero T
+		return zero, fmt.Errorf("no %s %s was found", kind, criteria)
+	default:
+		ids := make([]string, len(found))
+		for i, item := range found {
+			ids[i] = id(item)
+		}
+
+		var zero T
+		return zero, fmt.Errorf("%d %ss %s were found (IDs %s), but the lookup must match exactly one", len(found), kind, criteria, strings.Join(ids, ", "))
+	}
+}
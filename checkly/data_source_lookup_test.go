@@ -0,0 +1,132 @@
+package checkly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+func TestFindOne(t *testing.T) {
+	t.Parallel()
+
+	items := []snippetSummary{
+		{ID: 1, Name: "setup"},
+		{ID: 2, Name: "teardown"},
+		{ID: 3, Name: "teardown"},
+	}
+
+	tests := []struct {
+		name    string
+		find    string
+		wantID  int64
+		wantErr string
+	}{
+		{"one match", "setup", 1, ""},
+		{"no match", "login", 0, `no snippet named "login" was found`},
+		{"several matches", "teardown", 0, `2 snippets named "teardown" were found (IDs 2, 3), but the lookup must match exactly one`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := findOne(items, "snippet", "named "+strconv.Quote(tt.find),
+				func(s snippetSummary) string { return strconv.FormatInt(s.ID, 10) },
+				func(s snippetSummary) bool { return s.Name == tt.find },
+			)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("findOne() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("findOne() failed: %v", err)
+			}
+			if got.ID != tt.wantID {
+				t.Errorf("findOne() = %d, want %d", got.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestAlertChannelSummaryName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		channel alertChannelSummary
+		want    string
+	}{
+		{alertChannelSummary{Type: checkly.AlertTypeEmail, Config: map[string]any{"address": "ops@example.com"}}, "ops@example.com"},
+		{alertChannelSummary{Type: checkly.AlertTypeSlack, Config: map[string]any{"channel": "#alerts"}}, "#alerts"},
+		{alertChannelSummary{Type: checkly.AlertTypePagerduty, Config: map[string]any{"serviceName": "Payments"}}, "Payments"},
+		{alertChannelSummary{Type: checkly.AlertTypeWebhook, Config: map[string]any{"name": "Deploy hook"}}, "Deploy hook"},
+		{alertChannelSummary{Type: checkly.AlertTypeSMS}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.channel.Type, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.channel.name(); got != tt.want {
+				t.Errorf("name() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// snippetClient is a checkly.Client that only serves GetSnippet.
+type snippetClient struct {
+	checkly.Client
+	snippets map[int64]*checkly.Snippet
+}
+
+func (c snippetClient) GetSnippet(_ context.Context, id int64) (*checkly.Snippet, error) {
+	return c.snippets[id], nil
+}
+
+func TestDataSourceSnippetRead(t *testing.T) {
+	t.Parallel()
+
+	api := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/snippets" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		json.NewEncoder(w).Encode([]snippetSummary{{ID: 1, Name: "setup"}, {ID: 2, Name: "teardown"}})
+	})
+
+	meta := &providerMeta{
+		Client: snippetClient{snippets: map[int64]*checkly.Snippet{
+			2: {ID: 2, Name: "teardown", Script: "await browser.close()"},
+		}},
+		api: api,
+	}
+
+	d := dataSourceSnippet().TestResourceData()
+	d.Set("name", "teardown")
+
+	if err := dataSourceSnippetRead(context.Background(), d, meta); err != nil {
+		t.Fatalf("dataSourceSnippetRead failed: %v", err)
+	}
+
+	if d.Id() != "2" {
+		t.Errorf("ID = %q, want %q", d.Id(), "2")
+	}
+	if got := d.Get("script"); got != "await browser.close()" {
+		t.Errorf("script = %v, want %q", got, "await browser.close()")
+	}
+}
+
+func TestDataSourceSchemaFromResourceSchema(t *testing.T) {
+	t.Parallel()
+
+	for name, s := range dataSourceSchemaFromResourceSchema(resourceCheckGroup().Schema) {
+		if !s.Computed || s.Optional || s.Required || s.Default != nil || s.ForceNew {
+			t.Errorf("%s is not computed only", name)
+		}
+	}
+}
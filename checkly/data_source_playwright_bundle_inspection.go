@@ -0,0 +1,172 @@
+package checkly
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePlaywrightBundleInspection() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePlaywrightBundleInspectionRead,
+		Description: "Inspects a local Playwright code bundle archive the same " +
+			"way `checkly_playwright_code_bundle` does, and exposes what was " +
+			"detected. The inspection runs entirely offline; no API calls " +
+			"are made.",
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA-256 checksum of the archive.",
+			},
+			"file": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Path to the archive file.",
+				ValidateFunc: validateAll(validateFileExists(), validateGzipArchive()),
+			},
+			"project_path": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The directory of the Playwright project inside the archive, relative to its root. Conflicts with `playwright_config`.",
+				ConflictsWith: []string{"playwright_config"},
+				ValidateFunc:  validateArchiveRelativePath(),
+			},
+			"playwright_config": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The Playwright config file inside the archive, relative to its root. Conflicts with `project_path`.",
+				ConflictsWith: []string{"project_path"},
+				ValidateFunc:  validateArchiveRelativePath(),
+			},
+			"checksum_sha256": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA-256 checksum of the archive.",
+			},
+			"package_manager": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The package manager detected from the lockfile at the root of the archive. One of `npm`, `pnpm`, `yarn` or `bun`.",
+			},
+			"yarn_berry": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the lockfile is a Yarn Berry (v2+) lockfile.",
+			},
+			"yarn_pnp": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the Yarn Berry project installs with Plug'n'Play.",
+			},
+			"playwright_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of `@playwright/test` resolved in the lockfile.",
+			},
+			"cache_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The hash identifying the dependency cache. It covers the lockfile, every `package.json` and every `.npmrc` outside `node_modules`, and for Yarn Berry also the linker, every `.yarnrc.yml` and the files under `.yarn/patches`.",
+			},
+			"working_dir": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The directory, relative to the archive root, containing the `package.json` closest to the Playwright config.",
+			},
+			"engine": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The detected JavaScript runtime, `node` or `bun`. Empty when the archive does not specify one.",
+			},
+			"engine_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The runtime version the check will run with.",
+			},
+			"engine_raw_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The runtime version or constraint as written in the archive.",
+			},
+			"engine_source": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The file (and field, where applicable) the runtime version was detected from, for example `.nvmrc` or `package.json engines.node`.",
+			},
+			"engine_rule_set": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The engine rules the runtime version was resolved with. `embedded` for the rules shipped with the provider, otherwise the override configured on the provider followed by a digest of its content.",
+			},
+			"engine_notices": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Computed:    true,
+				Description: "Human-readable notes about how the runtime version was resolved, such as a version being adjusted to the closest supported one.",
+			},
+		},
+	}
+}
+
+func dataSourcePlaywrightBundleInspectionRead(
+	ctx context.Context,
+	d *schema.ResourceData,
+	client any,
+) diag.Diagnostics {
+	archive := &PlaywrightCodeBundlePrebuiltArchiveAttribute{
+		File: d.Get("file").(string),
+	}
+
+	if err := archive.InspectArchivePaths(); err != nil {
+		return diag.FromErr(err)
+	}
+
+	checksum, err := archive.ChecksumSha256()
+	if err != nil {
+		return diag.Errorf("failed to calculate archive checksum: %v", err)
+	}
+
+	inspection, err := archive.Inspect(WorkingDirOptions{
+		ProjectPath:      d.Get("project_path").(string),
+		PlaywrightConfig: d.Get("playwright_config").(string),
+	}, engineRuleSetFromMeta(client))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return dataSourceFromPlaywrightBundleInspection(checksum, inspection, d)
+}
+
+func dataSourceFromPlaywrightBundleInspection(
+	checksum string,
+	inspection *PlaywrightCodeBundleInspection,
+	d *schema.ResourceData,
+) diag.Diagnostics {
+	lockfile := inspection.Lockfile
+
+	notices := lockfile.EngineNotices
+	if notices == nil {
+		notices = []string{}
+	}
+
+	d.Set("checksum_sha256", checksum)
+	d.Set("package_manager", lockfile.PackageManager)
+	d.Set("yarn_berry", lockfile.YarnBerry)
+	d.Set("yarn_pnp", lockfile.YarnPnP)
+	d.Set("playwright_version", lockfile.PackageVersion)
+	d.Set("cache_hash", lockfile.ChecksumSha256)
+	d.Set("working_dir", inspection.WorkingDir)
+	d.Set("engine", lockfile.Engine)
+	d.Set("engine_version", lockfile.EngineVersion)
+	d.Set("engine_raw_version", lockfile.EngineRawVersion)
+	d.Set("engine_source", lockfile.EngineSource)
+	d.Set("engine_rule_set", lockfile.EngineRuleSet)
+	d.Set("engine_notices", notices)
+	d.SetId(checksum)
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package checkly
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPlaywrightBundleInspectionNpm(t *testing.T) {
+	config := `data "checkly_playwright_bundle_inspection" "test" {
+		file = "../fixtures/playwright-project-npm.tar.gz"
+	}`
+
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: config,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr(
+					"data.checkly_playwright_bundle_inspection.test",
+					"package_manager",
+					"npm",
+				),
+				resource.TestCheckResourceAttr(
+					"data.checkly_playwright_bundle_inspection.test",
+					"playwright_version",
+					"1.58.2",
+				),
+				resource.TestCheckResourceAttr(
+					"data.checkly_playwright_bundle_inspection.test",
+					"working_dir",
+					".",
+				),
+				resource.TestCheckResourceAttrSet(
+					"data.checkly_playwright_bundle_inspection.test",
+					"cache_hash",
+				),
+				resource.TestCheckResourceAttrPair(
+					"data.checkly_playwright_bundle_inspection.test",
+					"id",
+					"data.checkly_playwright_bundle_inspection.test",
+					"checksum_sha256",
+				),
+			),
+		},
+	})
+}
+
+func TestAccPlaywrightBundleInspectionMonorepo(t *testing.T) {
+	config := `data "checkly_playwright_bundle_inspection" "test" {
+		file = "../fixtures/playwright-project-monorepo-pnpm.tar.gz"
+	}`
+
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: config,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr(
+					"data.checkly_playwright_bundle_inspection.test",
+					"package_manager",
+					"pnpm",
+				),
+				resource.TestCheckResourceAttr(
+					"data.checkly_playwright_bundle_inspection.test",
+					"working_dir",
+					"packages/e2e",
+				),
+			),
+		},
+	})
+}
+
+func TestAccPlaywrightBundleInspectionNoLockfile(t *testing.T) {
+	config := `data "checkly_playwright_bundle_inspection" "test" {
+		file = "../fixtures/playwright-project-no-lockfile.tar.gz"
+	}`
+
+	accTestCase(t, []resource.TestStep{
+		{
+			Config:      config,
+			ExpectError: regexp.MustCompile(`no lockfile found at the root of the archive`),
+		},
+	})
+}
+
+func TestPlaywrightCodeBundleInspect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		file    string
+		wantErr string
+	}{
+		{
+			name: "npm project",
+			file: "../fixtures/playwright-project-npm.tar.gz",
+		},
+		{
+			name:    "no lockfile",
+			file:    "../fixtures/playwright-project-no-lockfile.tar.gz",
+			wantErr: "no lockfile found",
+		},
+		{
+			name:    "lockfile without @playwright/test",
+			file:    "../fixtures/playwright-project-no-playwright.tar.gz",
+			wantErr: "the lockfile does not contain @playwright/test",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			attr := PlaywrightCodeBundlePrebuiltArchiveAttribute{
+				File: tt.file,
+			}
+
+			inspection, err := attr.Inspect(WorkingDirOptions{}, nil)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Inspect() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Inspect failed: %v", err)
+			}
+
+			if inspection.Lockfile.PackageVersion != "1.58.2" {
+				t.Errorf("PackageVersion = %q, want %q", inspection.Lockfile.PackageVersion, "1.58.2")
+			}
+			if inspection.WorkingDir != "." {
+				t.Errorf("WorkingDir = %q, want %q", inspection.WorkingDir, ".")
+			}
+		})
+	}
+}
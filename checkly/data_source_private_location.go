@@ -0,0 +1,92 @@
+package checkly
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+func dataSourcePrivateLocation() *schema.Resource {
+	s := dataSourceSchemaFromResourceSchema(resourcePrivateLocation().Schema)
+
+	// The keys of a private location are only returned when it is created.
+	delete(s, "keys")
+
+	// Health warnings are a setting of the resource, not of the location.
+	delete(s, "require_healthy_agents")
+
+	s["name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		Description:  "The name of the private location to look up. Exactly one private location must have this name. Exactly one of `name` and `slug_name` must be set.",
+		ExactlyOneOf: []string{"name", "slug_name"},
+	}
+	s["slug_name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		Description:  "The slug name of the private location to look up. Exactly one of `name` and `slug_name` must be set.",
+		ExactlyOneOf: []string{"name", "slug_name"},
+	}
+
+	return &schema.Resource{
+		ReadContext: withAPIErrors(dataSourcePrivateLocationRead),
+		Description: "Looks up an existing private location by name or slug " +
+			"name, such as one owned by another team, and exposes the same " +
+			"attributes as the `checkly_private_location` resource, except " +
+			"for its keys.",
+		Schema: s,
+	}
+}
+
+// privateLocationSummary is a private location as listed by the API.
+type privateLocationSummary struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	SlugName string `json:"slugName"`
+}
+
+func dataSourcePrivateLocationRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
+	api, err := apiClientFromMeta(client)
+	if err != nil {
+		return err
+	}
+
+	// The API returns all private locations at once.
+	var locations []privateLocationSummary
+	if err := api.get(ctx, "/v1/private-locations", nil, &locations); err != nil {
+		return fmt.Errorf("failed to list private locations: %w", err)
+	}
+
+	criteria := ""
+	var match func(privateLocationSummary) bool
+	if slug := d.Get("slug_name").(string); slug != "" {
+		criteria = fmt.Sprintf("with slug name %q", slug)
+		match = func(pl privateLocationSummary) bool { return pl.SlugName == slug }
+	} else {
+		name := d.Get("name").(string)
+		criteria = fmt.Sprintf("named %q", name)
+		match = func(pl privateLocationSummary) bool { return pl.Name == name }
+	}
+
+	found, err := findOne(locations, "private location", criteria,
+		func(pl privateLocationSummary) string { return pl.ID },
+		match,
+	)
+	if err != nil {
+		return err
+	}
+
+	pl, err := client.(checkly.Client).GetPrivateLocation(ctx, found.ID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve private location %q: %w", found.ID, err)
+	}
+
+	d.SetId(found.ID)
+
+	return resourceDataFromPrivateLocation(pl, d)
+}
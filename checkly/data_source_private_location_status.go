@@ -0,0 +1,110 @@
+package checkly
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePrivateLocationStatus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: withAPIErrors(dataSourcePrivateLocationStatusRead),
+		Description: "Reports whether agents are connected to a private " +
+			"location, for example to verify in a `check` block that a " +
+			"location is healthy before checks are pointed at it.",
+		Schema: map[string]*schema.Schema{
+			"private_location_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The ID of the private location. Exactly one of `private_location_id` and `slug_name` must be set.",
+				ExactlyOneOf: []string{"private_location_id", "slug_name"},
+			},
+			"slug_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The slug name of the private location. Exactly one of `private_location_id` and `slug_name` must be set.",
+				ExactlyOneOf: []string{"private_location_id", "slug_name"},
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the private location.",
+			},
+			"agent_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of agents connected to the private location.",
+			},
+			"healthy": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether at least one agent is connected to the private location.",
+			},
+			"last_seen": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When an agent of the private location was last seen, or an empty string if none ever was.",
+			},
+			"queue_size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of check runs waiting for an agent, as of the latest metrics of the private location.",
+			},
+			"oldest_scheduled_check_run": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When the oldest queued check run was scheduled, as of the latest metrics of the private location, or an empty string if none is queued.",
+			},
+		},
+	}
+}
+
+func dataSourcePrivateLocationStatusRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
+	api, err := apiClientFromMeta(client)
+	if err != nil {
+		return err
+	}
+
+	id := d.Get("private_location_id").(string)
+	if slug := d.Get("slug_name").(string); slug != "" {
+		// The API returns all private locations at once.
+		var locations []privateLocationSummary
+		if err := api.get(ctx, "/v1/private-locations", nil, &locations); err != nil {
+			return fmt.Errorf("failed to list private locations: %w", err)
+		}
+
+		found, err := findOne(locations, "private location", fmt.Sprintf("with slug name %q", slug),
+			func(pl privateLocationSummary) string { return pl.ID },
+			func(pl privateLocationSummary) bool { return pl.SlugName == slug },
+		)
+		if err != nil {
+			return err
+		}
+		id = found.ID
+	}
+
+	status, err := fetchPrivateLocationStatus(ctx, api, id, time.Now())
+	if err != nil {
+		return err
+	}
+
+	d.SetId(id)
+
+	return dataSourceFromPrivateLocationStatus(status, d)
+}
+
+func dataSourceFromPrivateLocationStatus(s privateLocationStatus, d *schema.ResourceData) error {
+	d.Set("private_location_id", d.Id())
+	d.Set("slug_name", s.SlugName)
+	d.Set("name", s.Name)
+	d.Set("agent_count", s.AgentCount)
+	d.Set("healthy", s.healthy())
+	d.Set("last_seen", s.LastSeen)
+	d.Set("queue_size", s.QueueSize)
+	d.Set("oldest_scheduled_check_run", s.OldestScheduledCheckRun)
+	return nil
+}
@@ -0,0 +1,39 @@
+package checkly
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPrivateLocationDataSource(t *testing.T) {
+	rInt := acctest.RandInt()
+	slug := fmt.Sprintf("tf-test-pl-ds-%d", rInt%100000)
+
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: fmt.Sprintf(`
+				resource "checkly_private_location" "test" {
+					name      = "Private Location %d"
+					slug_name = "%s"
+				}
+
+				data "checkly_private_location" "by_slug" {
+					slug_name = checkly_private_location.test.slug_name
+				}
+
+				data "checkly_private_location" "by_name" {
+					name = checkly_private_location.test.name
+				}
+			`, rInt, slug),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrPair("data.checkly_private_location.by_slug", "id", "checkly_private_location.test", "id"),
+				resource.TestCheckResourceAttr("data.checkly_private_location.by_slug", "name", fmt.Sprintf("Private Location %d", rInt)),
+				resource.TestCheckResourceAttrPair("data.checkly_private_location.by_name", "id", "checkly_private_location.test", "id"),
+				resource.TestCheckResourceAttr("data.checkly_private_location.by_name", "slug_name", slug),
+			),
+		},
+	})
+}
@@ -0,0 +1,210 @@
+package checkly
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceRuntimes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: withAPIErrors(dataSourceRuntimesRead),
+		Description: "Lists the runtimes that checks and check groups can run " +
+			"with, along with their capabilities and deprecation dates, and " +
+			"the default runtime of the account.",
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA-256 checksum of the IDs of the listed runtimes.",
+			},
+			"include_deprecated": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to list deprecated runtimes. (Default `true`).",
+			},
+			"multi_step_support": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only list runtimes that support `MULTI_STEP` checks, if `true`, or that do not, if `false`.",
+			},
+			"default_runtime_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the runtime that checks run with when neither they nor their group set one.",
+			},
+			"latest_runtime_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the newest listed runtime that is not deprecated, if any.",
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The IDs of the listed runtimes, in the same order as `runtimes`.",
+			},
+			"runtimes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The listed runtimes, from newest to oldest.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the runtime, such as `2024.09`.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the runtime.",
+						},
+						"stage": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The release stage of the runtime, such as `STABLE`, `BETA` or `DEPRECATED`.",
+						},
+						"deprecated": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether checks should move off the runtime.",
+						},
+						"end_of_life": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date, as `YYYY-MM-DD`, on which the runtime stops being available, if one is set.",
+						},
+						"default": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the runtime is the default of the account.",
+						},
+						"multi_step_support": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the runtime supports `MULTI_STEP` checks.",
+						},
+						"dependencies": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "The versions of the packages the runtime provides, by package name.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// runtimesFilter selects runtimes. Zero values match every runtime.
+type runtimesFilter struct {
+	ExcludeDeprecated bool
+	MultiStepSupport  *bool
+}
+
+func runtimesFilterFromResourceData(d *schema.ResourceData) runtimesFilter {
+	filter := runtimesFilter{
+		ExcludeDeprecated: !d.Get("include_deprecated").(bool),
+	}
+
+	// Optional booleans cannot tell false from unset, but the configuration
+	// can.
+	if config := d.GetRawConfig(); !config.IsNull() {
+		if v := config.GetAttr("multi_step_support"); !v.IsNull() {
+			multiStep := v.True()
+			filter.MultiStepSupport = &multiStep
+		}
+	}
+
+	return filter
+}
+
+func (f runtimesFilter) matches(r runtimeInfo) bool {
+	switch {
+	case f.ExcludeDeprecated && r.deprecated():
+		return false
+	case f.MultiStepSupport != nil && *f.MultiStepSupport != r.MultiStepSupport:
+		return false
+	}
+	return true
+}
+
+func dataSourceRuntimesRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
+	m := client.(*providerMeta)
+
+	runtimes, accountDefault, err := m.runtimes.load(ctx, m.api)
+	if err != nil {
+		return err
+	}
+
+	filter := runtimesFilterFromResourceData(d)
+
+	// The catalog is shared, so it is filtered and sorted in a copy.
+	runtimes = slices.DeleteFunc(slices.Clone(runtimes), func(r runtimeInfo) bool {
+		return !filter.matches(r)
+	})
+
+	// Runtime IDs are versions of the form YYYY.MM, which sort by age.
+	slices.SortFunc(runtimes, func(a, b runtimeInfo) int {
+		return strings.Compare(b.Name, a.Name)
+	})
+
+	return dataSourceFromRuntimes(runtimes, accountDefault, d)
+}
+
+func dataSourceFromRuntimes(runtimes []runtimeInfo, accountDefault string, d *schema.ResourceData) error {
+	ids := make([]string, len(runtimes))
+	list := make([]tfMap, len(runtimes))
+	latest := ""
+	for i, r := range runtimes {
+		ids[i] = r.Name
+
+		endOfLife := ""
+		if eol, ok := r.endOfLife(); ok {
+			endOfLife = eol.Format(time.DateOnly)
+		}
+
+		list[i] = tfMap{
+			"id":                 r.Name,
+			"description":        r.Description,
+			"stage":              r.Stage,
+			"deprecated":         r.deprecated(),
+			"end_of_life":        endOfLife,
+			"default":            r.Name == accountDefault,
+			"multi_step_support": r.MultiStepSupport,
+			"dependencies":       r.Dependencies,
+		}
+
+		if latest == "" && !r.deprecated() {
+			latest = r.Name
+		}
+	}
+
+	if err := d.Set("default_runtime_id", accountDefault); err != nil {
+		return fmt.Errorf("error setting default_runtime_id: %w", err)
+	}
+	if err := d.Set("latest_runtime_id", latest); err != nil {
+		return fmt.Errorf("error setting latest_runtime_id: %w", err)
+	}
+	if err := d.Set("ids", ids); err != nil {
+		return fmt.Errorf("error setting ids: %w", err)
+	}
+	if err := d.Set("runtimes", list); err != nil {
+		return fmt.Errorf("error setting runtimes: %w", err)
+	}
+
+	d.SetId(checksumSha256(strings.NewReader(strings.Join(ids, "\n"))))
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package checkly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDataSourceRuntimesRead(t *testing.T) {
+	t.Parallel()
+
+	api := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/runtimes":
+			json.NewEncoder(w).Encode([]runtimeInfo{
+				{Name: "2023.09", Stage: "DEPRECATED", RuntimeEndOfLife: "2026-01-31", MultiStepSupport: true},
+				{Name: "2025.04", Stage: "STABLE", MultiStepSupport: true},
+				{Name: "2024.09", Stage: "STABLE"},
+			})
+		case accountValidationPath:
+			json.NewEncoder(w).Encode(map[string]string{"runtimeId": "2024.09"})
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	meta := &providerMeta{
+		api: api,
+	}
+
+	d := dataSourceRuntimes().TestResourceData()
+
+	if err := dataSourceRuntimesRead(context.Background(), d, meta); err != nil {
+		t.Fatalf("dataSourceRuntimesRead failed: %v", err)
+	}
+
+	if diff := cmp.Diff([]any{"2025.04", "2024.09", "2023.09"}, d.Get("ids").([]any)); diff != "" {
+		t.Errorf("ids mismatch (-want +got):\n%s", diff)
+	}
+	if got := d.Get("default_runtime_id"); got != "2024.09" {
+		t.Errorf("default_runtime_id = %v, want %q", got, "2024.09")
+	}
+	if got := d.Get("latest_runtime_id"); got != "2025.04" {
+		t.Errorf("latest_runtime_id = %v, want %q", got, "2025.04")
+	}
+	if got := d.Get("runtimes.1.default"); got != true {
+		t.Errorf("runtimes.1.default = %v, want true", got)
+	}
+	if got := d.Get("runtimes.2.end_of_life"); got != "2026-01-31" {
+		t.Errorf("runtimes.2.end_of_life = %v, want %q", got, "2026-01-31")
+	}
+	if got := d.Get("runtimes.2.deprecated"); got != true {
+		t.Errorf("runtimes.2.deprecated = %v, want true", got)
+	}
+}
+
+func TestRuntimesFilterMatches(t *testing.T) {
+	t.Parallel()
+
+	yes := true
+	deprecated := runtimeInfo{Name: "2023.09", Stage: "DEPRECATED", MultiStepSupport: true}
+	stable := runtimeInfo{Name: "2024.09", Stage: "STABLE"}
+
+	cases := []struct {
+		filter runtimesFilter
+		r      runtimeInfo
+		want   bool
+	}{
+		{runtimesFilter{}, deprecated, true},
+		{runtimesFilter{ExcludeDeprecated: true}, deprecated, false},
+		{runtimesFilter{ExcludeDeprecated: true}, stable, true},
+		{runtimesFilter{MultiStepSupport: &yes}, stable, false},
+		{runtimesFilter{MultiStepSupport: &yes}, deprecated, true},
+	}
+
+	for _, c := range cases {
+		if got := c.filter.matches(c.r); got != c.want {
+			t.Errorf("%+v.matches(%s) = %v, want %v", c.filter, c.r.Name, got, c.want)
+		}
+	}
+}
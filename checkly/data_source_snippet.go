@@ -0,0 +1,65 @@
+package checkly
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+func dataSourceSnippet() *schema.Resource {
+	s := dataSourceSchemaFromResourceSchema(resourceSnippet().Schema)
+
+	s["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "The name of the snippet to look up. Exactly one snippet must have this name.",
+	}
+
+	return &schema.Resource{
+		ReadContext: withAPIErrors(dataSourceSnippetRead),
+		Description: "Looks up an existing snippet by name, and exposes the " +
+			"same attributes as the `checkly_snippet` resource.",
+		Schema: s,
+	}
+}
+
+// snippetSummary is a snippet as listed by the API.
+type snippetSummary struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+func dataSourceSnippetRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
+	name := d.Get("name").(string)
+
+	api, err := apiClientFromMeta(client)
+	if err != nil {
+		return err
+	}
+
+	snippets, err := listAPIPages[snippetSummary](ctx, api, "/v1/snippets", nil)
+	if err != nil {
+		return fmt.Errorf("failed to list snippets: %w", err)
+	}
+
+	found, err := findOne(snippets, "snippet", fmt.Sprintf("named %q", name),
+		func(s snippetSummary) string { return strconv.FormatInt(s.ID, 10) },
+		func(s snippetSummary) bool { return s.Name == name },
+	)
+	if err != nil {
+		return err
+	}
+
+	snippet, err := client.(checkly.Client).GetSnippet(ctx, found.ID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve snippet %d: %w", found.ID, err)
+	}
+
+	d.SetId(strconv.FormatInt(found.ID, 10))
+
+	return resourceDataFromSnippet(snippet, d)
+}
@@ -0,0 +1,44 @@
+package checkly
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSnippetDataSource(t *testing.T) {
+	name := fmt.Sprintf("tf-test-snippet-%d", acctest.RandInt())
+
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: fmt.Sprintf(`
+				resource "checkly_snippet" "test" {
+					name   = %[1]q
+					script = "console.log('bar')"
+				}
+
+				data "checkly_snippet" "test" {
+					name = %[1]q
+
+					depends_on = [checkly_snippet.test]
+				}
+			`, name),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrPair("data.checkly_snippet.test", "id", "checkly_snippet.test", "id"),
+				resource.TestCheckResourceAttr("data.checkly_snippet.test", "script", "console.log('bar')"),
+			),
+		},
+	})
+}
+
+func TestAccSnippetDataSourceNotFound(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config:      `data "checkly_snippet" "test" { name = "tf-test-snippet-that-does-not-exist" }`,
+			ExpectError: regexp.MustCompile(`no snippet named "tf-test-snippet-that-does-not-exist" was found`),
+		},
+	})
+}
@@ -12,7 +12,7 @@ import (
 
 func dataSourceStaticIPs() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceStaticIPsRead,
+		ReadContext: withAPIErrors(dataSourceStaticIPsRead),
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:        schema.TypeString,
@@ -51,12 +51,10 @@ func dataSourceStaticIPs() *schema.Resource {
 	}
 }
 
-func dataSourceStaticIPsRead(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
+func dataSourceStaticIPsRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	staticIPs, err := client.(checkly.Client).GetStaticIPs(ctx)
-	defer cancel()
 	if err != nil {
-		return fmt.Errorf("dateSourceStaticIPsRead: API error: %w", err)
+		return fmt.Errorf("failed to retrieve static IPs: %w", err)
 	}
 	return dataSourceFromStaticIPs(staticIPs, d)
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/Masterminds/semver/v3"
@@ -19,6 +20,7 @@ type EngineDetectionResult struct {
 	RawVersion string
 	Source     string
 	Notices    []string
+	RuleSet    string
 }
 
 func parseNodeVersionFile(content []byte) string {
@@ -32,13 +34,24 @@ func parseNvmrcFile(content []byte) string {
 	if s == "" {
 		return ""
 	}
-	if strings.HasPrefix(s, "lts/") || s == "lts" || s == "node" || s == "stable" || s == "latest" {
+	if parseNvmrcAlias(content) != "" {
 		return ""
 	}
 	s = strings.TrimPrefix(s, "v")
 	return s
 }
 
+// parseNvmrcAlias returns the nvm alias (e.g. "lts/iron" or "node") held by
+// an .nvmrc, or an empty string if it holds a version.
+func parseNvmrcAlias(content []byte) string {
+	s := strings.TrimSpace(string(content))
+	l := strings.ToLower(s)
+	if strings.HasPrefix(l, "lts/") || l == "lts" || l == "node" || l == "stable" || l == "latest" || l == "current" {
+		return s
+	}
+	return ""
+}
+
 func parseToolVersionsFile(content []byte) (nodeVersion string, bunVersion string) {
 	for _, line := range strings.Split(string(content), "\n") {
 		line = strings.TrimSpace(line)
@@ -82,6 +95,181 @@ func parsePackageJSONEngines(content []byte) (nodeRange string, bunRange string)
 	return pkg.Engines.Node, pkg.Engines.Bun
 }
 
+// parseMiseTOMLFile reads the node and bun versions from the [tools] table of
+// a mise.toml. Only the subset of TOML mise configs use for tools is
+// understood: string values, arrays of strings (the first one wins) and
+// inline tables with a version key. Aliases such as "lts" or "latest" are
+// ignored.
+func parseMiseTOMLFile(content []byte) (nodeVersion string, bunVersion string) {
+	section := ""
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			header, _, _ := strings.Cut(line[1:], "]")
+			section = strings.TrimSpace(header)
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.Trim(strings.TrimSpace(key), `"'`)
+		if section != "tools" {
+			var found bool
+			if key, found = strings.CutPrefix(key, "tools."); !found || section != "" {
+				continue
+			}
+		}
+
+		version := parseMiseToolVersion(strings.TrimSpace(value))
+		switch key {
+		case "node", "nodejs":
+			if nodeVersion == "" {
+				nodeVersion = version
+			}
+		case "bun":
+			if bunVersion == "" {
+				bunVersion = version
+			}
+		}
+	}
+	return nodeVersion, bunVersion
+}
+
+func parseMiseToolVersion(value string) string {
+	switch {
+	case strings.HasPrefix(value, "["):
+		value = strings.TrimSpace(value[1:])
+	case strings.HasPrefix(value, "{"):
+		i := strings.Index(value, "version")
+		if i < 0 {
+			return ""
+		}
+		_, value, _ = strings.Cut(value[i+len("version"):], "=")
+		value = strings.TrimSpace(value)
+	}
+
+	if len(value) < 2 || (value[0] != '"' && value[0] != '\'') {
+		return ""
+	}
+	end := strings.IndexByte(value[1:], value[0])
+	if end < 0 {
+		return ""
+	}
+	version := strings.TrimPrefix(value[1:end+1], "v")
+	if version == "" || version[0] < '0' || version[0] > '9' {
+		return ""
+	}
+	return version
+}
+
+// parsePackageJSONVolta returns the Node.js version pinned by Volta in the
+// "volta" field of package.json.
+func parsePackageJSONVolta(content []byte) string {
+	var pkg struct {
+		Volta struct {
+			Node string `json:"node"`
+		} `json:"volta"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(pkg.Volta.Node, "v")
+}
+
+// parsePackageJSONDevEnginesRuntime returns the node and bun version ranges
+// from the "devEngines.runtime" field of package.json, which is either a
+// single {name, version} object or an array of them.
+func parsePackageJSONDevEnginesRuntime(content []byte) (nodeRange string, bunRange string) {
+	var pkg struct {
+		DevEngines struct {
+			Runtime json.RawMessage `json:"runtime"`
+		} `json:"devEngines"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil || len(pkg.DevEngines.Runtime) == 0 {
+		return "", ""
+	}
+
+	type runtime struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	var runtimes []runtime
+	if err := json.Unmarshal(pkg.DevEngines.Runtime, &runtimes); err != nil {
+		var single runtime
+		if err := json.Unmarshal(pkg.DevEngines.Runtime, &single); err != nil {
+			return "", ""
+		}
+		runtimes = []runtime{single}
+	}
+
+	for _, r := range runtimes {
+		switch r.Name {
+		case "node":
+			if nodeRange == "" {
+				nodeRange = r.Version
+			}
+		case "bun":
+			if bunRange == "" {
+				bunRange = r.Version
+			}
+		}
+	}
+	return nodeRange, bunRange
+}
+
+// parsePackageJSONPackageManager splits the "packageManager" field of
+// package.json (e.g. "pnpm@9.1.0+sha512.abc") into name and version.
+func parsePackageJSONPackageManager(content []byte) (name string, version string) {
+	var pkg struct {
+		PackageManager string `json:"packageManager"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return "", ""
+	}
+	name, version, _ = strings.Cut(pkg.PackageManager, "@")
+	version, _, _ = strings.Cut(version, "+")
+	return name, version
+}
+
+// packageManagerFieldNotice cross-checks the "packageManager" field of the
+// root package.json, if any, with the package manager derived from the
+// lockfile. Corepack refuses to install with a different package manager
+// than the one declared, so a mismatch is reported as a notice; it returns
+// an empty string if they agree.
+func packageManagerFieldNotice(packageJSON []byte, packageManager string, yarnBerry bool) string {
+	name, version := parsePackageJSONPackageManager(packageJSON)
+	if name == "" {
+		return ""
+	}
+
+	if name != packageManager {
+		return fmt.Sprintf(
+			"package.json declares packageManager %q, but the lockfile at the root of the archive belongs to %s.",
+			name+"@"+version, packageManager,
+		)
+	}
+
+	if name == "yarn" {
+		if v, err := semver.NewVersion(version); err == nil && (v.Major() >= 2) != yarnBerry {
+			lockfile := "Yarn Classic (v1)"
+			if yarnBerry {
+				lockfile = "Yarn Berry (v2+)"
+			}
+			return fmt.Sprintf(
+				"package.json declares packageManager %q, but yarn.lock is a %s lockfile.",
+				name+"@"+version, lockfile,
+			)
+		}
+	}
+
+	return ""
+}
+
 func resolveNodeMajorVersion(raw string) string {
 	if raw == "" {
 		return ""
@@ -121,8 +309,8 @@ func extractVersionFromConstraint(constraint string) string {
 
 // resolveVersionForEngine resolves a raw version string using the engine rules.
 // Returns the resolved version (or empty if denied/unparseable) and any notices.
-func resolveVersionForEngine(engineName string, rawVersion string) (string, []string) {
-	config, ok := engineConfigs[engineName]
+func resolveVersionForEngine(rules *engineRuleSet, engineName string, rawVersion string) (string, []string) {
+	config, ok := rules.Configs[engineName]
 	if !ok {
 		return rawVersion, nil
 	}
@@ -146,12 +334,12 @@ func resolveVersionForEngine(engineName string, rawVersion string) (string, []st
 
 // resolveConstraintForEngine resolves a semver constraint (from package.json engines)
 // through the engine rules. Extracts the base version, then applies rules.
-func resolveConstraintForEngine(engineName string, constraint string) (string, []string) {
+func resolveConstraintForEngine(rules *engineRuleSet, engineName string, constraint string) (string, []string) {
 	// Try semver.minVersion first for accurate resolution
 	c, err := semver.NewConstraint(constraint)
 	if err == nil {
 		// Test versions from the rules' targets to find the best match
-		config := engineConfigs[engineName]
+		config := rules.Configs[engineName]
 		var best string
 		var bestVer *semver.Version
 		targets := collectTargets(config)
@@ -168,7 +356,7 @@ func resolveConstraintForEngine(engineName string, constraint string) (string, [
 			}
 		}
 		if best != "" {
-			return resolveVersionForEngine(engineName, best)
+			return resolveVersionForEngine(rules, engineName, best)
 		}
 	}
 
@@ -177,7 +365,7 @@ func resolveConstraintForEngine(engineName string, constraint string) (string, [
 	if extracted == "" {
 		return "", nil
 	}
-	return resolveVersionForEngine(engineName, extracted)
+	return resolveVersionForEngine(rules, engineName, extracted)
 }
 
 // collectTargets returns all unique target versions from the rules (plus default).
@@ -197,7 +385,9 @@ func collectTargets(config engineVersionConfig) []string {
 	return targets
 }
 
-func detectEngine(files map[string][]byte, packageManager string) *EngineDetectionResult {
+// detectEngine detects the engine of a project from files, the version and
+// configuration files at its root, and resolves its version with rules.
+func detectEngine(rules *engineRuleSet, files map[string][]byte, packageManager string) *EngineDetectionResult {
 	preferBun := packageManager == "bun"
 
 	type candidate struct {
@@ -210,11 +400,15 @@ func detectEngine(files map[string][]byte, packageManager string) *EngineDetecti
 
 	var nodeCandidate, bunCandidate *candidate
 
+	// notices are about sources that were skipped, and are reported
+	// whichever candidate wins.
+	var notices []string
+
 	tryNode := func(raw, source string) {
 		if nodeCandidate != nil {
 			return
 		}
-		resolved, notices := resolveVersionForEngine("node", raw)
+		resolved, notices := resolveVersionForEngine(rules, "node", raw)
 		nodeCandidate = &candidate{engine: "node", version: resolved, rawVersion: raw, source: source, notices: notices}
 	}
 
@@ -222,7 +416,7 @@ func detectEngine(files map[string][]byte, packageManager string) *EngineDetecti
 		if bunCandidate != nil {
 			return
 		}
-		resolved, notices := resolveVersionForEngine("bun", raw)
+		resolved, notices := resolveVersionForEngine(rules, "bun", raw)
 		bunCandidate = &candidate{engine: "bun", version: resolved, rawVersion: raw, source: source, notices: notices}
 	}
 
@@ -233,11 +427,30 @@ func detectEngine(files map[string][]byte, packageManager string) *EngineDetecti
 		}
 	}
 
+	tryNodeAlias := func(alias, source string) {
+		if nodeCandidate != nil {
+			return
+		}
+		major, ok := resolveNodeAlias(alias)
+		if !ok {
+			// Leave the version to the sources further down.
+			notices = append(notices, fmt.Sprintf("The Node.js version alias %q in %s is not known and was ignored.", alias, source))
+			return
+		}
+		resolved, notices := resolveVersionForEngine(rules, "node", major)
+		notices = append([]string{
+			fmt.Sprintf("The Node.js version alias %q in %s was resolved to Node.js %s.", alias, source, major),
+		}, notices...)
+		nodeCandidate = &candidate{engine: "node", version: resolved, rawVersion: alias, source: source, notices: notices}
+	}
+
 	// 2. .nvmrc (pinning file, only if no .node-version found)
 	if nodeCandidate == nil {
 		if raw, ok := files[".nvmrc"]; ok {
 			if parsed := parseNvmrcFile(raw); parsed != "" {
 				tryNode(parsed, ".nvmrc")
+			} else if alias := parseNvmrcAlias(raw); alias != "" {
+				tryNodeAlias(alias, ".nvmrc")
 			}
 		}
 	}
@@ -262,16 +475,51 @@ func detectEngine(files map[string][]byte, packageManager string) *EngineDetecti
 		}
 	}
 
-	// 5. package.json engines (range file — only consulted when no pinning file was found for that engine)
+	// 5. mise.toml, then .mise.toml (pinning files)
+	for _, name := range []string{"mise.toml", ".mise.toml"} {
+		if raw, ok := files[name]; ok {
+			miseNodeVersion, miseBunVersion := parseMiseTOMLFile(raw)
+			if miseNodeVersion != "" && nodeCandidate == nil {
+				tryNode(miseNodeVersion, name)
+			}
+			if miseBunVersion != "" && bunCandidate == nil {
+				tryBun(miseBunVersion, name)
+			}
+		}
+	}
+
+	// 6. package.json volta (pinning field)
+	if raw, ok := files["package.json"]; ok && nodeCandidate == nil {
+		if voltaNodeVersion := parsePackageJSONVolta(raw); voltaNodeVersion != "" {
+			tryNode(voltaNodeVersion, "package.json volta.node")
+		}
+	}
+
+	// 7. package.json devEngines.runtime (range field — only consulted when no pinning file was found for that engine)
+	if raw, ok := files["package.json"]; ok {
+		nodeRange, bunRange := parsePackageJSONDevEnginesRuntime(raw)
+		if nodeCandidate == nil && nodeRange != "" {
+			if resolved, notices := resolveConstraintForEngine(rules, "node", nodeRange); resolved != "" {
+				nodeCandidate = &candidate{engine: "node", version: resolved, rawVersion: nodeRange, source: "package.json devEngines.runtime", notices: notices}
+			}
+		}
+		if bunCandidate == nil && bunRange != "" {
+			if resolved, notices := resolveConstraintForEngine(rules, "bun", bunRange); resolved != "" {
+				bunCandidate = &candidate{engine: "bun", version: resolved, rawVersion: bunRange, source: "package.json devEngines.runtime", notices: notices}
+			}
+		}
+	}
+
+	// 8. package.json engines (range field — only consulted when no pinning file was found for that engine)
 	if raw, ok := files["package.json"]; ok {
 		nodeRange, bunRange := parsePackageJSONEngines(raw)
 		if nodeCandidate == nil && nodeRange != "" {
-			if resolved, notices := resolveConstraintForEngine("node", nodeRange); resolved != "" {
+			if resolved, notices := resolveConstraintForEngine(rules, "node", nodeRange); resolved != "" {
 				nodeCandidate = &candidate{engine: "node", version: resolved, rawVersion: nodeRange, source: "package.json engines.node", notices: notices}
 			}
 		}
 		if bunCandidate == nil && bunRange != "" {
-			if resolved, notices := resolveConstraintForEngine("bun", bunRange); resolved != "" {
+			if resolved, notices := resolveConstraintForEngine(rules, "bun", bunRange); resolved != "" {
 				bunCandidate = &candidate{engine: "bun", version: resolved, rawVersion: bunRange, source: "package.json engines.bun", notices: notices}
 			}
 		}
@@ -282,7 +530,8 @@ func detectEngine(files map[string][]byte, packageManager string) *EngineDetecti
 			Engine:     &EngineInfo{Name: c.engine, Version: c.version},
 			RawVersion: c.rawVersion,
 			Source:     c.source,
-			Notices:    c.notices,
+			Notices:    append(slices.Clone(notices), c.notices...),
+			RuleSet:    rules.ID,
 		}
 	}
 
@@ -302,5 +551,9 @@ func detectEngine(files map[string][]byte, packageManager string) *EngineDetecti
 		}
 	}
 
+	if len(notices) > 0 {
+		return &EngineDetectionResult{Notices: notices, RuleSet: rules.ID}
+	}
+
 	return nil
 }
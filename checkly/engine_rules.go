@@ -1,9 +1,17 @@
 package checkly
 
 import (
+	"bytes"
+	"crypto/sha256"
 	_ "embed"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/Masterminds/semver/v3"
@@ -12,6 +20,9 @@ import (
 //go:embed engines.json
 var enginesJSON []byte
 
+//go:embed node-aliases.json
+var nodeAliasesJSON []byte
+
 type engineRule struct {
 	Source string `json:"source"`
 	Target string `json:"target"`
@@ -34,8 +45,14 @@ type enginesFile struct {
 	Engines []engineEntry `json:"engines"`
 }
 
+// engineConfigs holds the rules embedded in the provider. Resolution runs
+// against the rule set of the provider, which may merge overrides on top of
+// these.
 var engineConfigs map[string]engineVersionConfig
 
+// embeddedEngineRuleSetID identifies the rules embedded in the provider.
+const embeddedEngineRuleSetID = "embedded"
+
 func init() {
 	var f enginesFile
 	if err := json.Unmarshal(enginesJSON, &f); err != nil {
@@ -45,6 +62,286 @@ func init() {
 	for _, e := range f.Engines {
 		engineConfigs[e.Name] = e.Versions
 	}
+	if err := validateEngineConfigs(engineConfigs); err != nil {
+		panic(fmt.Sprintf("invalid embedded engines.json: %v", err))
+	}
+	embeddedEngineRuleSet = &engineRuleSet{
+		ID:      embeddedEngineRuleSetID,
+		Configs: engineConfigs,
+	}
+}
+
+// engineRuleSet is the set of engine rules resolution runs against.
+type engineRuleSet struct {
+	// ID names where the rules came from. It is "embedded" for the rules
+	// shipped with the provider, and otherwise names the override and a
+	// short digest of its content, e.g. "embedded+file:rules.json@1a2b3c4d5e6f".
+	// Only the base name of a file is used, so that the ID doesn't depend on
+	// where the configuration is checked out.
+	ID      string
+	Configs map[string]engineVersionConfig
+}
+
+// embeddedEngineRuleSet wraps engineConfigs; it is set up in init.
+var embeddedEngineRuleSet *engineRuleSet
+
+// engineRuleSetFromMeta returns the rule set configured for the provider, or
+// the embedded one if none was configured. Each provider configuration, such
+// as an alias, carries its own rule set.
+func engineRuleSetFromMeta(meta any) *engineRuleSet {
+	if m, ok := meta.(*providerMeta); ok && m.engineRules != nil {
+		return m.engineRules
+	}
+	return embeddedEngineRuleSet
+}
+
+// loadEngineRuleSet builds the rule set from the provider configuration.
+// At most one of file and inline may be set; with neither, the embedded
+// rules are returned.
+func loadEngineRuleSet(file, inline string) (*engineRuleSet, error) {
+	switch {
+	case file != "" && inline != "":
+		return nil, errors.New("only one of engine_rules_file and engine_rules can be set")
+	case file != "":
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read engine rules file %q: %w", file, err)
+		}
+		rs, err := newEngineRuleSet("file:"+filepath.Base(file), content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid engine rules file %q: %w", file, err)
+		}
+		return rs, nil
+	case inline != "":
+		rs, err := newEngineRuleSet("inline", []byte(inline))
+		if err != nil {
+			return nil, fmt.Errorf("invalid engine rules: %w", err)
+		}
+		return rs, nil
+	default:
+		return embeddedEngineRuleSet, nil
+	}
+}
+
+// newEngineRuleSet parses content, which has the same format as the embedded
+// engines.json, merges it over the embedded rules and validates the result.
+func newEngineRuleSet(label string, content []byte) (*engineRuleSet, error) {
+	dec := json.NewDecoder(bytes.NewReader(content))
+	dec.DisallowUnknownFields()
+
+	var f enginesFile
+	if err := dec.Decode(&f); err != nil {
+		return nil, fmt.Errorf("failed to parse rules: %w", err)
+	}
+
+	configs, err := mergeEngineConfigs(engineConfigs, f)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateEngineConfigs(configs); err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(content)
+
+	return &engineRuleSet{
+		ID:      fmt.Sprintf("%s+%s@%s", embeddedEngineRuleSetID, label, hex.EncodeToString(sum[:6])),
+		Configs: configs,
+	}, nil
+}
+
+// mergeEngineConfigs returns a copy of base with override applied. For an
+// engine present in both, a non-empty override default replaces the base
+// default, and the override rules are placed before the base rules so that
+// they take precedence (the first matching rule wins). Engines only present
+// in override are added as-is.
+func mergeEngineConfigs(
+	base map[string]engineVersionConfig,
+	override enginesFile,
+) (map[string]engineVersionConfig, error) {
+	merged := make(map[string]engineVersionConfig, len(base))
+	for name, config := range base {
+		merged[name] = config
+	}
+
+	seen := make(map[string]bool)
+	for _, e := range override.Engines {
+		if e.Name == "" {
+			return nil, errors.New("engine without a name")
+		}
+		if seen[e.Name] {
+			return nil, fmt.Errorf("engine %q is listed more than once", e.Name)
+		}
+		seen[e.Name] = true
+
+		config, ok := merged[e.Name]
+		if !ok {
+			merged[e.Name] = e.Versions
+			continue
+		}
+
+		if e.Versions.Default != "" {
+			config.Default = e.Versions.Default
+		}
+
+		rules := make([]engineRule, 0, len(e.Versions.Rules)+len(config.Rules))
+		rules = append(rules, e.Versions.Rules...)
+		rules = append(rules, config.Rules...)
+		config.Rules = rules
+
+		merged[e.Name] = config
+	}
+
+	return merged, nil
+}
+
+// validateEngineConfigs checks that every default and target is a version,
+// every source is a valid constraint, every action is known, and that no
+// chain of followed rules loops back onto itself.
+func validateEngineConfigs(configs map[string]engineVersionConfig) error {
+	names := make([]string, 0, len(configs))
+	for name := range configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		if err := validateEngineVersionConfig(configs[name]); err != nil {
+			errs = append(errs, fmt.Errorf("engine %q: %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateEngineVersionConfig(config engineVersionConfig) error {
+	if config.Default == "" {
+		return errors.New("no default version")
+	}
+	if _, err := semver.NewVersion(config.Default); err != nil {
+		return fmt.Errorf("default %q is not a valid version: %v", config.Default, err)
+	}
+
+	constraints := make([]*semver.Constraints, len(config.Rules))
+	for i, rule := range config.Rules {
+		c, err := semver.NewConstraint(rule.Source)
+		if err != nil {
+			return fmt.Errorf("rule %d: source %q is not a valid constraint: %v", i, rule.Source, err)
+		}
+		constraints[i] = c
+
+		switch rule.Action {
+		case "", "allow", "deny":
+		default:
+			return fmt.Errorf("rule %d: action %q is not one of \"allow\" or \"deny\"", i, rule.Action)
+		}
+
+		if rule.Target != "" {
+			if _, err := semver.NewVersion(rule.Target); err != nil {
+				return fmt.Errorf("rule %d: target %q is not a valid version: %v", i, rule.Target, err)
+			}
+		}
+	}
+
+	// Follow every target the same way resolveEngineVersion would, and
+	// report a chain that revisits a version.
+	for _, start := range collectTargets(config) {
+		chain := []string{start}
+		seen := map[string]bool{start: true}
+		current := start
+
+		for {
+			sv, err := semver.NewVersion(current)
+			if err != nil {
+				break
+			}
+
+			var match *engineRule
+			for i := range config.Rules {
+				if constraints[i].Check(sv) {
+					match = &config.Rules[i]
+					break
+				}
+			}
+			if match == nil || match.Action == "deny" || !match.Follow || match.Target == "" || match.Target == current {
+				break
+			}
+
+			current = match.Target
+			chain = append(chain, current)
+			if seen[current] {
+				return fmt.Errorf("rules form a cycle: %s", strings.Join(chain, " -> "))
+			}
+			seen[current] = true
+		}
+	}
+
+	return nil
+}
+
+// nodeLTSRelease is a Node.js LTS release line.
+type nodeLTSRelease struct {
+	Codename string `json:"codename"`
+	Major    string `json:"major"`
+}
+
+// nodeAliasesFile is the table of the version aliases nvm understands. It
+// is maintained by hand, so resolution works offline.
+type nodeAliasesFile struct {
+	// Current is the major of the latest Node.js release line, which the
+	// "node", "stable", "latest" and "current" aliases resolve to.
+	Current string `json:"current"`
+
+	// LTS lists the LTS release lines, oldest first.
+	LTS []nodeLTSRelease `json:"lts"`
+}
+
+var nodeAliases nodeAliasesFile
+
+func init() {
+	if err := json.Unmarshal(nodeAliasesJSON, &nodeAliases); err != nil {
+		panic(fmt.Sprintf("failed to parse embedded node-aliases.json: %v", err))
+	}
+	if nodeAliases.Current == "" || len(nodeAliases.LTS) == 0 {
+		panic("invalid embedded node-aliases.json: current and lts must be set")
+	}
+}
+
+// resolveNodeAlias resolves an nvm version alias, such as "lts/*",
+// "lts/iron", "lts/-1" or "node", to a Node.js major version. It reports
+// false if the alias is unknown.
+func resolveNodeAlias(alias string) (string, bool) {
+	alias = strings.ToLower(alias)
+
+	switch alias {
+	case "node", "stable", "latest", "current":
+		return nodeAliases.Current, true
+	case "lts", "lts/*":
+		return nodeAliases.LTS[len(nodeAliases.LTS)-1].Major, true
+	}
+
+	name, ok := strings.CutPrefix(alias, "lts/")
+	if !ok {
+		return "", false
+	}
+
+	if back, ok := strings.CutPrefix(name, "-"); ok {
+		n, err := strconv.Atoi(back)
+		if err != nil || n < 0 || n >= len(nodeAliases.LTS) {
+			return "", false
+		}
+		return nodeAliases.LTS[len(nodeAliases.LTS)-1-n].Major, true
+	}
+
+	for _, release := range nodeAliases.LTS {
+		if release.Codename == name {
+			return release.Major, true
+		}
+	}
+
+	return "", false
 }
 
 // EngineResolution is the result of resolving an engine version through the rules.
@@ -0,0 +1,216 @@
+package checkly
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewEngineRuleSet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		rules   string
+		wantErr string
+	}{
+		{
+			name: "new node major",
+			rules: `{"engines": [{"name": "node", "versions": {"default": "24", "rules": [
+				{"source": "^28", "target": "28", "action": "allow"}
+			]}}]}`,
+		},
+		{
+			name:  "new engine",
+			rules: `{"engines": [{"name": "deno", "versions": {"default": "2", "rules": []}}]}`,
+		},
+		{
+			name:    "unknown field",
+			rules:   `{"engines": [{"name": "node", "version": {}}]}`,
+			wantErr: `unknown field "version"`,
+		},
+		{
+			name:    "invalid constraint",
+			rules:   `{"engines": [{"name": "node", "versions": {"rules": [{"source": "^^22", "target": "22"}]}}]}`,
+			wantErr: `engine "node": rule 0: source "^^22" is not a valid constraint`,
+		},
+		{
+			name:    "invalid target",
+			rules:   `{"engines": [{"name": "node", "versions": {"rules": [{"source": "^22", "target": "latest"}]}}]}`,
+			wantErr: `engine "node": rule 0: target "latest" is not a valid version`,
+		},
+		{
+			name:    "invalid default",
+			rules:   `{"engines": [{"name": "bun", "versions": {"default": "canary"}}]}`,
+			wantErr: `engine "bun": default "canary" is not a valid version`,
+		},
+		{
+			name:    "unknown action",
+			rules:   `{"engines": [{"name": "bun", "versions": {"rules": [{"source": "~1.1", "action": "warn"}]}}]}`,
+			wantErr: `engine "bun": rule 0: action "warn" is not one of "allow" or "deny"`,
+		},
+		{
+			name: "cycle",
+			rules: `{"engines": [{"name": "node", "versions": {"rules": [
+				{"source": "^22", "target": "24", "follow": true},
+				{"source": "^24", "target": "22", "follow": true}
+			]}}]}`,
+			wantErr: `engine "node": rules form a cycle: 22 -> 24 -> 22`,
+		},
+		{
+			name: "duplicate engine",
+			rules: `{"engines": [
+				{"name": "node", "versions": {}},
+				{"name": "node", "versions": {}}
+			]}`,
+			wantErr: `engine "node" is listed more than once`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rs, err := newEngineRuleSet("inline", []byte(tt.rules))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("newEngineRuleSet() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newEngineRuleSet failed: %v", err)
+			}
+			if !strings.HasPrefix(rs.ID, "embedded+inline@") {
+				t.Errorf("ID = %q, want prefix %q", rs.ID, "embedded+inline@")
+			}
+		})
+	}
+}
+
+func TestEngineRuleSetOverridesEmbeddedRules(t *testing.T) {
+	t.Parallel()
+
+	rs, err := newEngineRuleSet("inline", []byte(`{"engines": [
+		{"name": "node", "versions": {"default": "24", "rules": [
+			{"source": ">=27", "target": "28", "action": "allow"}
+		]}},
+		{"name": "bun", "versions": {"rules": [
+			{"source": "~1.1", "action": "deny", "notice": "Bun ${SOURCE} is deprecated."}
+		]}}
+	]}`))
+	if err != nil {
+		t.Fatalf("newEngineRuleSet failed: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		engine      string
+		version     string
+		wantVersion string
+		wantDenied  bool
+	}{
+		{"override takes precedence", "node", "28", "28", false},
+		{"embedded rules still apply", "node", "23", "24", false},
+		{"default is replaced", "node", "not-a-version", "24", false},
+		{"override denies", "bun", "1.1", "", true},
+		{"embedded bun rules still apply", "bun", "1.2", "1.3", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			res := resolveEngineVersion(tt.version, rs.Configs[tt.engine])
+			if res.Denied != tt.wantDenied {
+				t.Errorf("Denied = %v, want %v", res.Denied, tt.wantDenied)
+			}
+			if res.Version != tt.wantVersion {
+				t.Errorf("Version = %q, want %q", res.Version, tt.wantVersion)
+			}
+		})
+	}
+
+	// The embedded rules must be left untouched.
+	if res := resolveEngineVersion("28", engineConfigs["node"]); res.Version != "26" {
+		t.Errorf("embedded Version = %q, want %q", res.Version, "26")
+	}
+}
+
+func TestLoadEngineRuleSet(t *testing.T) {
+	t.Parallel()
+
+	rules := `{"engines": [{"name": "node", "versions": {"default": "24"}}]}`
+	file := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(file, []byte(rules), 0644); err != nil {
+		t.Fatalf("write rules: %v", err)
+	}
+
+	rs, err := loadEngineRuleSet("", "")
+	if err != nil {
+		t.Fatalf("loadEngineRuleSet failed: %v", err)
+	}
+	if rs.ID != "embedded" {
+		t.Errorf("ID = %q, want %q", rs.ID, "embedded")
+	}
+
+	fromFile, err := loadEngineRuleSet(file, "")
+	if err != nil {
+		t.Fatalf("loadEngineRuleSet failed: %v", err)
+	}
+	if want := "embedded+file:rules.json@"; !strings.HasPrefix(fromFile.ID, want) {
+		t.Errorf("ID = %q, want prefix %q", fromFile.ID, want)
+	}
+
+	inline, err := loadEngineRuleSet("", rules)
+	if err != nil {
+		t.Fatalf("loadEngineRuleSet failed: %v", err)
+	}
+	if digest := fromFile.ID[strings.LastIndex(fromFile.ID, "@"):]; !strings.HasSuffix(inline.ID, digest) {
+		t.Errorf("ID = %q, want the same digest as %q", inline.ID, fromFile.ID)
+	}
+
+	if _, err := loadEngineRuleSet(filepath.Join(t.TempDir(), "missing.json"), ""); err == nil {
+		t.Error("loadEngineRuleSet should fail for a missing file")
+	}
+	if _, err := loadEngineRuleSet(file, rules); err == nil {
+		t.Error("loadEngineRuleSet should fail when both a file and inline rules are set")
+	}
+}
+
+func TestEngineRuleSetPerProviderConfiguration(t *testing.T) {
+	t.Parallel()
+
+	overridden, err := newEngineRuleSet("inline", []byte(`{"engines": [
+		{"name": "node", "versions": {"rules": [
+			{"source": ">=27", "target": "28", "action": "allow"}
+		]}}
+	]}`))
+	if err != nil {
+		t.Fatalf("newEngineRuleSet failed: %v", err)
+	}
+
+	// Two aliases of the provider, configured in any order, each resolve
+	// with their own rules.
+	aliases := []struct {
+		meta        any
+		wantVersion string
+		wantRuleSet string
+	}{
+		{&providerMeta{engineRules: overridden}, "28", overridden.ID},
+		{&providerMeta{}, "26", embeddedEngineRuleSetID},
+		{nil, "26", embeddedEngineRuleSetID},
+	}
+
+	files := map[string][]byte{".node-version": []byte("28")}
+	for _, alias := range aliases {
+		got := detectEngine(engineRuleSetFromMeta(alias.meta), files, "npm")
+		if got == nil || got.Engine == nil {
+			t.Fatalf("detectEngine() = %+v, want an engine", got)
+		}
+		if got.Engine.Version != alias.wantVersion || got.RuleSet != alias.wantRuleSet {
+			t.Errorf("detectEngine() = %s with %s, want %s with %s", got.Engine.Version, got.RuleSet, alias.wantVersion, alias.wantRuleSet)
+		}
+	}
+}
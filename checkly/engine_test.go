@@ -1,6 +1,7 @@
 package checkly
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -40,6 +41,7 @@ func TestParseNvmrcFile(t *testing.T) {
 		{"node alias", "node", ""},
 		{"stable alias", "stable", ""},
 		{"latest alias", "latest", ""},
+		{"current alias", "current", ""},
 		{"empty", "", ""},
 	}
 	for _, tt := range tests {
@@ -264,10 +266,11 @@ func TestDetectEngine(t *testing.T) {
 			wantVersion:    "1.3",
 		},
 		{
-			name:           "nvmrc with lts skips, returns nil",
+			name:           "nvmrc with lts resolves the latest LTS",
 			files:          map[string][]byte{".nvmrc": []byte("lts/*")},
 			packageManager: "pnpm",
-			wantNil:        true,
+			wantName:       "node",
+			wantVersion:    "24",
 		},
 		{
 			name:           "tool-versions with nodejs",
@@ -374,7 +377,7 @@ func TestDetectEngine(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := detectEngine(tt.files, tt.packageManager)
+			got := detectEngine(embeddedEngineRuleSet, tt.files, tt.packageManager)
 			if tt.wantNil {
 				if got != nil {
 					t.Errorf("detectEngine() = %+v, want nil", got)
@@ -396,3 +399,300 @@ func TestDetectEngine(t *testing.T) {
 		})
 	}
 }
+
+func TestParseMiseTOMLFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantNode string
+		wantBun  string
+	}{
+		{"tools table", "[tools]\nnode = \"22.4.0\"", "22.4.0", ""},
+		{"bun and node", "[tools]\nbun = '1.3.11'\nnode = \"v24\"", "24", "1.3.11"},
+		{"array uses first", "[tools]\nnode = [\"22\", \"20\"]", "22", ""},
+		{"inline table", "[tools]\nnode = { version = \"24.1.0\", postinstall = \"corepack enable\" }", "24.1.0", ""},
+		{"dotted key", "tools.node = \"22\"", "22", ""},
+		{"other tables ignored", "[env]\nnode = \"22\"\n[tools]\npython = \"3.12\"", "", ""},
+		{"header with comment", "[tools] # pinned\nnode = \"22\" # LTS", "22", ""},
+		{"alias ignored", "[tools]\nnode = \"lts\"", "", ""},
+		{"empty", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotNode, gotBun := parseMiseTOMLFile([]byte(tt.content))
+			if gotNode != tt.wantNode || gotBun != tt.wantBun {
+				t.Errorf("parseMiseTOMLFile(%q) = (%q, %q), want (%q, %q)",
+					tt.content, gotNode, gotBun, tt.wantNode, tt.wantBun)
+			}
+		})
+	}
+}
+
+func TestParsePackageJSONVolta(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"node pinned", `{"volta":{"node":"22.4.0","npm":"10.8.1"}}`, "22.4.0"},
+		{"no volta", `{"name":"test"}`, ""},
+		{"malformed json", `{invalid`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parsePackageJSONVolta([]byte(tt.content))
+			if got != tt.want {
+				t.Errorf("parsePackageJSONVolta(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePackageJSONDevEnginesRuntime(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantNode string
+		wantBun  string
+	}{
+		{"single runtime", `{"devEngines":{"runtime":{"name":"node","version":"^24"}}}`, "^24", ""},
+		{"runtime list", `{"devEngines":{"runtime":[{"name":"bun","version":">=1.3"},{"name":"node","version":">=22"}]}}`, ">=22", ">=1.3"},
+		{"other runtime", `{"devEngines":{"runtime":{"name":"deno","version":"^2"}}}`, "", ""},
+		{"no devEngines", `{"engines":{"node":">=22"}}`, "", ""},
+		{"malformed runtime", `{"devEngines":{"runtime":"node"}}`, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotNode, gotBun := parsePackageJSONDevEnginesRuntime([]byte(tt.content))
+			if gotNode != tt.wantNode || gotBun != tt.wantBun {
+				t.Errorf("parsePackageJSONDevEnginesRuntime(%q) = (%q, %q), want (%q, %q)",
+					tt.content, gotNode, gotBun, tt.wantNode, tt.wantBun)
+			}
+		})
+	}
+}
+
+func TestDetectEngineSource(t *testing.T) {
+	tests := []struct {
+		name           string
+		files          map[string][]byte
+		packageManager string
+		wantName       string
+		wantVersion    string
+		wantSource     string
+	}{
+		{
+			name:           "mise.toml",
+			files:          map[string][]byte{"mise.toml": []byte("[tools]\nnode = \"24.1.0\"")},
+			packageManager: "npm",
+			wantName:       "node",
+			wantVersion:    "24",
+			wantSource:     "mise.toml",
+		},
+		{
+			name:           ".mise.toml with bun",
+			files:          map[string][]byte{".mise.toml": []byte("[tools]\nbun = \"1.3.11\"")},
+			packageManager: "bun",
+			wantName:       "bun",
+			wantVersion:    "1.3",
+			wantSource:     ".mise.toml",
+		},
+		{
+			name: "tool-versions wins over mise.toml",
+			files: map[string][]byte{
+				".tool-versions": []byte("nodejs 22.14.0"),
+				"mise.toml":      []byte("[tools]\nnode = \"24\""),
+			},
+			packageManager: "npm",
+			wantName:       "node",
+			wantVersion:    "22",
+			wantSource:     ".tool-versions",
+		},
+		{
+			name:           "volta",
+			files:          map[string][]byte{"package.json": []byte(`{"volta":{"node":"22.4.0"},"engines":{"node":">=24"}}`)},
+			packageManager: "npm",
+			wantName:       "node",
+			wantVersion:    "22",
+			wantSource:     "package.json volta.node",
+		},
+		{
+			name:           "devEngines.runtime wins over engines",
+			files:          map[string][]byte{"package.json": []byte(`{"devEngines":{"runtime":{"name":"node","version":"^24"}},"engines":{"node":">=22"}}`)},
+			packageManager: "pnpm",
+			wantName:       "node",
+			wantVersion:    "24",
+			wantSource:     "package.json devEngines.runtime",
+		},
+		{
+			name: "pinning file wins over devEngines.runtime",
+			files: map[string][]byte{
+				".nvmrc":       []byte("22"),
+				"package.json": []byte(`{"devEngines":{"runtime":{"name":"node","version":"^24"}}}`),
+			},
+			packageManager: "npm",
+			wantName:       "node",
+			wantVersion:    "22",
+			wantSource:     ".nvmrc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := detectEngine(embeddedEngineRuleSet, tt.files, tt.packageManager)
+			if got == nil || got.Engine == nil {
+				t.Fatalf("detectEngine() = %+v, want an engine", got)
+			}
+			if got.Engine.Name != tt.wantName {
+				t.Errorf("detectEngine().Engine.Name = %q, want %q", got.Engine.Name, tt.wantName)
+			}
+			if got.Engine.Version != tt.wantVersion {
+				t.Errorf("detectEngine().Engine.Version = %q, want %q", got.Engine.Version, tt.wantVersion)
+			}
+			if got.Source != tt.wantSource {
+				t.Errorf("detectEngine().Source = %q, want %q", got.Source, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestPackageManagerFieldNotice(t *testing.T) {
+	tests := []struct {
+		name           string
+		packageJSON    string
+		packageManager string
+		yarnBerry      bool
+		wantNotice     string
+	}{
+		{"no field", `{"name":"test"}`, "npm", false, ""},
+		{"no package.json", ``, "npm", false, ""},
+		{"matching pnpm", `{"packageManager":"pnpm@9.1.0+sha512.abc"}`, "pnpm", false, ""},
+		{"matching yarn berry", `{"packageManager":"yarn@4.1.0"}`, "yarn", true, ""},
+		{"matching yarn classic", `{"packageManager":"yarn@1.22.22"}`, "yarn", false, ""},
+		{"different package manager", `{"packageManager":"pnpm@9.1.0"}`, "npm", false, `package.json declares packageManager "pnpm@9.1.0", but the lockfile at the root of the archive belongs to npm`},
+		{"yarn berry with classic lockfile", `{"packageManager":"yarn@4.1.0"}`, "yarn", false, `yarn.lock is a Yarn Classic (v1) lockfile`},
+		{"yarn classic with berry lockfile", `{"packageManager":"yarn@1.22.22"}`, "yarn", true, `yarn.lock is a Yarn Berry (v2+) lockfile`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notice := packageManagerFieldNotice([]byte(tt.packageJSON), tt.packageManager, tt.yarnBerry)
+			if tt.wantNotice == "" {
+				if notice != "" {
+					t.Errorf("packageManagerFieldNotice() = %q, want none", notice)
+				}
+				return
+			}
+			if !strings.Contains(notice, tt.wantNotice) {
+				t.Errorf("packageManagerFieldNotice() = %q, want it to contain %q", notice, tt.wantNotice)
+			}
+		})
+	}
+}
+
+func TestResolveNodeAlias(t *testing.T) {
+	tests := []struct {
+		alias  string
+		want   string
+		wantOk bool
+	}{
+		{"lts/*", "24", true},
+		{"lts", "24", true},
+		{"lts/iron", "20", true},
+		{"lts/Jod", "22", true},
+		{"lts/-1", "22", true},
+		{"lts/-0", "24", true},
+		{"node", "26", true},
+		{"stable", "26", true},
+		{"latest", "26", true},
+		{"lts/unobtainium", "", false},
+		{"lts/-100", "", false},
+		{"iojs", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.alias, func(t *testing.T) {
+			got, ok := resolveNodeAlias(tt.alias)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("resolveNodeAlias(%q) = (%q, %v), want (%q, %v)", tt.alias, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestDetectEngineUnknownNvmrcAlias(t *testing.T) {
+	notice := `The Node.js version alias "lts/unobtainium" in .nvmrc is not known and was ignored.`
+
+	// Detection goes on with the sources further down.
+	got := detectEngine(embeddedEngineRuleSet, map[string][]byte{
+		".nvmrc":       []byte("lts/unobtainium"),
+		"package.json": []byte(`{"volta":{"node":"24.1.0"}}`),
+	}, "npm")
+	if got == nil || got.Engine == nil {
+		t.Fatalf("detectEngine() = %+v, want an engine", got)
+	}
+	if got.Engine.Version != "24" || got.Source != "package.json volta.node" {
+		t.Errorf("detectEngine() = Node.js %s from %s, want Node.js 24 from package.json volta.node", got.Engine.Version, got.Source)
+	}
+	if len(got.Notices) == 0 || got.Notices[0] != notice {
+		t.Errorf("detectEngine().Notices = %q, want %q first", got.Notices, notice)
+	}
+
+	// Without other sources, the notice is all there is.
+	got = detectEngine(embeddedEngineRuleSet, map[string][]byte{".nvmrc": []byte("lts/unobtainium")}, "npm")
+	if got == nil || got.Engine != nil {
+		t.Fatalf("detectEngine() = %+v, want notices but no engine", got)
+	}
+	if strings.Join(got.Notices, "\n") != notice {
+		t.Errorf("detectEngine().Notices = %q, want %q", got.Notices, notice)
+	}
+}
+
+func TestDetectEngineNvmrcAlias(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		wantVersion string
+		wantNotices []string
+	}{
+		{
+			name:        "lts codename",
+			content:     "lts/iron",
+			wantVersion: "22",
+			wantNotices: []string{
+				`The Node.js version alias "lts/iron" in .nvmrc was resolved to Node.js 20.`,
+				"Node.js 20 (LTS) is EOL since 30 Apr 2026 and no longer available. Consider Node.js 22 (LTS) instead.",
+			},
+		},
+		{
+			name:        "latest LTS",
+			content:     "lts/*\n",
+			wantVersion: "24",
+			wantNotices: []string{
+				`The Node.js version alias "lts/*" in .nvmrc was resolved to Node.js 24.`,
+			},
+		},
+		{
+			name:        "current release",
+			content:     "node",
+			wantVersion: "26",
+			wantNotices: []string{
+				`The Node.js version alias "node" in .nvmrc was resolved to Node.js 26.`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := detectEngine(embeddedEngineRuleSet, map[string][]byte{".nvmrc": []byte(tt.content)}, "npm")
+			if got == nil || got.Engine == nil {
+				t.Fatalf("detectEngine() = %+v, want an engine", got)
+			}
+			if got.Engine.Version != tt.wantVersion {
+				t.Errorf("detectEngine().Engine.Version = %q, want %q", got.Engine.Version, tt.wantVersion)
+			}
+			if got.RawVersion != strings.TrimSpace(tt.content) {
+				t.Errorf("detectEngine().RawVersion = %q, want %q", got.RawVersion, strings.TrimSpace(tt.content))
+			}
+			if strings.Join(got.Notices, "\n") != strings.Join(tt.wantNotices, "\n") {
+				t.Errorf("detectEngine().Notices = %q, want %q", got.Notices, tt.wantNotices)
+			}
+		})
+	}
+}
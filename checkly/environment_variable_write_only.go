@@ -0,0 +1,134 @@
+package checkly
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Environment variables take their value either from value, which is kept in
+// the state, or from the write-only value_wo, which is sent to the API but
+// never stored. Since Terraform can't tell when a write-only value changes,
+// value_version stands in for it: the value is only sent again when
+// value_version changes, and a non-zero value_version in the state marks a
+// variable whose value is write-only.
+const (
+	writeOnlyValueAttributeName = "value_wo"
+	valueVersionAttributeName   = "value_version"
+)
+
+// writeOnlyValueFromConfig returns the write-only value at path in the
+// configuration of d, if it is set. The configuration is only available when
+// the resource is created or updated.
+func writeOnlyValueFromConfig(d *schema.ResourceData, path cty.Path) (string, bool) {
+	v, diags := d.GetRawConfigAt(path)
+	if diags.HasError() || v.IsNull() || !v.IsKnown() || !v.Type().Equals(cty.String) {
+		return "", false
+	}
+	return v.AsString(), true
+}
+
+// validateEnvironmentVariableValue checks that exactly one of value and
+// value_wo is set in obj, the configuration of an environment variable at
+// path, and that value_version accompanies value_wo. It suggests value_wo for
+// secrets when Terraform supports write-only attributes.
+func validateEnvironmentVariableValue(obj cty.Value, path cty.Path, writeOnlyAllowed bool) diag.Diagnostics {
+	if obj.IsNull() || !obj.IsKnown() {
+		return nil
+	}
+
+	value := obj.GetAttr("value")
+	valueWO := obj.GetAttr(writeOnlyValueAttributeName)
+	version := obj.GetAttr(valueVersionAttributeName)
+	secret := obj.GetAttr("secret")
+
+	attrError := func(attr, summary, detail string) diag.Diagnostics {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       summary,
+			Detail:        detail,
+			AttributePath: path.Copy().GetAttr(attr),
+		}}
+	}
+
+	switch {
+	case !valueWO.IsNull() && !writeOnlyAllowed:
+		return attrError(writeOnlyValueAttributeName, "Write-only value not supported",
+			"value_wo is a write-only attribute, which requires Terraform 1.11 or later. Use value instead, or upgrade Terraform.")
+	case !value.IsNull() && !valueWO.IsNull():
+		return attrError(writeOnlyValueAttributeName, "Conflicting environment variable values",
+			"Only one of value and value_wo may be set.")
+	case value.IsNull() && valueWO.IsNull():
+		return attrError("value", "Missing environment variable value",
+			"One of value and value_wo must be set.")
+	case !valueWO.IsNull() && version.IsNull():
+		return attrError(valueVersionAttributeName, "Missing value version",
+			"value_version must be set along with value_wo, and changed whenever value_wo changes, since Terraform can't tell when a write-only value changes.")
+	case valueWO.IsNull() && !version.IsNull():
+		return attrError(valueVersionAttributeName, "Unexpected value version",
+			"value_version only applies to value_wo.")
+	}
+
+	if writeOnlyAllowed && secret.IsKnown() && !secret.IsNull() && secret.True() && !value.IsNull() {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Secret value stored in state",
+			Detail: "The value of this secret environment variable is stored in the Terraform state. " +
+				"Use value_wo and value_version instead, so that the value is sent to Checkly but never stored.",
+			AttributePath: path.Copy().GetAttr("value"),
+		}}
+	}
+
+	return nil
+}
+
+// ValidateEnvironmentVariableValueConfig validates the value of the
+// checkly_environment_variable resource.
+func ValidateEnvironmentVariableValueConfig(_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	resp.Diagnostics = append(resp.Diagnostics, validateEnvironmentVariableValue(req.RawConfig, cty.Path{}, req.WriteOnlyAttributesAllowed)...)
+}
+
+// ValidateEnvironmentVariableBlocksConfig validates the values of the
+// environment_variable blocks of a check or group.
+func ValidateEnvironmentVariableBlocksConfig(_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	if req.RawConfig.IsNull() || !req.RawConfig.IsKnown() {
+		return
+	}
+
+	blocks := req.RawConfig.GetAttr(environmentVariableAttributeName)
+	if blocks.IsNull() || !blocks.IsKnown() {
+		return
+	}
+
+	for it := blocks.ElementIterator(); it.Next(); {
+		k, obj := it.Element()
+		i, _ := k.AsBigFloat().Int64()
+
+		path := cty.GetAttrPath(environmentVariableAttributeName).IndexInt(int(i))
+		resp.Diagnostics = append(resp.Diagnostics, validateEnvironmentVariableValue(obj, path, req.WriteOnlyAttributesAllowed)...)
+	}
+}
+
+// writeOnlyValueVersions returns the value_version of the environment
+// variables in prior, the environment_variable blocks in the state, by key.
+func writeOnlyValueVersions(prior []any) map[string]int {
+	versions := make(map[string]int)
+	for _, item := range prior {
+		tm, ok := item.(tfMap)
+		if !ok {
+			continue
+		}
+		if version, _ := tm[valueVersionAttributeName].(int); version != 0 {
+			versions[tm["key"].(string)] = version
+		}
+	}
+	return versions
+}
+
+// writeOnlyValueError describes a write-only value that could not be read.
+func writeOnlyValueError(key string) error {
+	return fmt.Errorf("the write-only value of environment variable %q is not available; set value_wo in the configuration", key)
+}
@@ -0,0 +1,140 @@
+package checkly
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	checkly "github.com/checkly/checkly-go-sdk"
+)
+
+func environmentVariableConfig(value, valueWO cty.Value, version cty.Value, secret bool) cty.Value {
+	return cty.ObjectVal(map[string]cty.Value{
+		"key":                       cty.StringVal("API_KEY"),
+		"value":                     value,
+		writeOnlyValueAttributeName: valueWO,
+		valueVersionAttributeName:   version,
+		"locked":                    cty.False,
+		"secret":                    cty.BoolVal(secret),
+	})
+}
+
+func TestValidateEnvironmentVariableValue(t *testing.T) {
+	t.Parallel()
+
+	nullString := cty.NullVal(cty.String)
+	nullNumber := cty.NullVal(cty.Number)
+
+	cases := []struct {
+		name             string
+		config           cty.Value
+		writeOnlyAllowed bool
+		wantSeverity     diag.Severity
+		wantSummary      string
+	}{
+		{
+			name:             "value",
+			config:           environmentVariableConfig(cty.StringVal("v"), nullString, nullNumber, false),
+			writeOnlyAllowed: true,
+		},
+		{
+			name:             "write-only value",
+			config:           environmentVariableConfig(nullString, cty.StringVal("v"), cty.NumberIntVal(1), true),
+			writeOnlyAllowed: true,
+		},
+		{
+			name:         "write-only value unsupported",
+			config:       environmentVariableConfig(nullString, cty.StringVal("v"), cty.NumberIntVal(1), true),
+			wantSeverity: diag.Error,
+			wantSummary:  "Write-only value not supported",
+		},
+		{
+			name:             "both values",
+			config:           environmentVariableConfig(cty.StringVal("v"), cty.StringVal("v"), cty.NumberIntVal(1), false),
+			writeOnlyAllowed: true,
+			wantSeverity:     diag.Error,
+			wantSummary:      "Conflicting environment variable values",
+		},
+		{
+			name:             "no value",
+			config:           environmentVariableConfig(nullString, nullString, nullNumber, false),
+			writeOnlyAllowed: true,
+			wantSeverity:     diag.Error,
+			wantSummary:      "Missing environment variable value",
+		},
+		{
+			name:             "write-only value without version",
+			config:           environmentVariableConfig(nullString, cty.StringVal("v"), nullNumber, true),
+			writeOnlyAllowed: true,
+			wantSeverity:     diag.Error,
+			wantSummary:      "Missing value version",
+		},
+		{
+			name:             "version without write-only value",
+			config:           environmentVariableConfig(cty.StringVal("v"), nullString, cty.NumberIntVal(1), false),
+			writeOnlyAllowed: true,
+			wantSeverity:     diag.Error,
+			wantSummary:      "Unexpected value version",
+		},
+		{
+			name:             "secret value",
+			config:           environmentVariableConfig(cty.StringVal("v"), nullString, nullNumber, true),
+			writeOnlyAllowed: true,
+			wantSeverity:     diag.Warning,
+			wantSummary:      "Secret value stored in state",
+		},
+		{
+			name:   "secret value without write-only support",
+			config: environmentVariableConfig(cty.StringVal("v"), nullString, nullNumber, true),
+		},
+		{
+			name:             "unknown value",
+			config:           environmentVariableConfig(cty.UnknownVal(cty.String), nullString, nullNumber, false),
+			writeOnlyAllowed: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := validateEnvironmentVariableValue(c.config, cty.Path{}, c.writeOnlyAllowed)
+			if c.wantSummary == "" {
+				if len(diags) > 0 {
+					t.Fatalf("unexpected diagnostics: %+v", diags)
+				}
+				return
+			}
+			if len(diags) != 1 {
+				t.Fatalf("got %d diagnostics, want 1: %+v", len(diags), diags)
+			}
+			if diags[0].Severity != c.wantSeverity || diags[0].Summary != c.wantSummary {
+				t.Errorf("got %v %q, want %v %q", diags[0].Severity, diags[0].Summary, c.wantSeverity, c.wantSummary)
+			}
+		})
+	}
+}
+
+func TestListFromEnvironmentVariablesWriteOnly(t *testing.T) {
+	t.Parallel()
+
+	evs := []checkly.EnvironmentVariable{
+		{Key: "API_URL", Value: "https://api.example.com"},
+		{Key: "API_KEY", Value: "hunter2", Secret: true},
+	}
+	prior := []any{
+		tfMap{"key": "API_URL", "value": "https://api.example.com", valueVersionAttributeName: 0},
+		tfMap{"key": "API_KEY", "value": "", valueVersionAttributeName: 3},
+	}
+
+	want := []tfMap{
+		{"key": "API_URL", "value": "https://api.example.com", "locked": false, "secret": false},
+		{"key": "API_KEY", "value": "", "locked": false, "secret": true, valueVersionAttributeName: 3},
+	}
+
+	if diff := cmp.Diff(want, listFromEnvironmentVariables(evs, prior)); diff != "" {
+		t.Errorf("listFromEnvironmentVariables() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,160 @@
+package checkly
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// environmentVariableKeyRegexp matches the keys Checkly accepts for
+// environment variables.
+var environmentVariableKeyRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// readEnvironmentVariablesFile parses the environment variables in the file
+// at path, which is a JSON object of strings if its extension is .json, and a
+// dotenv file otherwise.
+func readEnvironmentVariablesFile(path string) (map[string]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read environment variables file: %w", err)
+	}
+
+	var vars map[string]string
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		vars, err = parseEnvironmentVariablesJSON(b)
+	} else {
+		vars, err = parseDotenv(string(b))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse environment variables file %q: %w", path, err)
+	}
+
+	return vars, nil
+}
+
+// parseEnvironmentVariablesJSON parses a JSON object of strings.
+func parseEnvironmentVariablesJSON(b []byte) (map[string]string, error) {
+	var vars map[string]string
+	if err := json.Unmarshal(b, &vars); err != nil {
+		return nil, fmt.Errorf("must be a JSON object of strings: %w", err)
+	}
+	if vars == nil {
+		return nil, errors.New("must be a JSON object of strings, got null")
+	}
+	return vars, nil
+}
+
+// parseDotenv parses the common subset of the dotenv format: KEY=value lines,
+// optionally prefixed with export, blank lines and # comments. Single-quoted
+// values are taken literally, double-quoted values may span lines and support
+// the \n, \r, \t, \" and \\ escapes, and unquoted values end at a # that
+// follows whitespace.
+func parseDotenv(s string) (map[string]string, error) {
+	vars := make(map[string]string)
+
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+
+		key, rest, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected KEY=value", lineNo)
+		}
+		key = strings.TrimSpace(key)
+		if !environmentVariableKeyRegexp.MatchString(key) {
+			return nil, fmt.Errorf("line %d: invalid key %q", lineNo, key)
+		}
+		if _, ok := vars[key]; ok {
+			return nil, fmt.Errorf("line %d: duplicate key %q", lineNo, key)
+		}
+
+		rest = strings.TrimLeft(rest, " \t")
+
+		var value string
+		switch {
+		case strings.HasPrefix(rest, "'"):
+			end := strings.Index(rest[1:], "'")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated single-quoted value", lineNo)
+			}
+			value = rest[1 : end+1]
+		case strings.HasPrefix(rest, `"`):
+			// Double-quoted values may continue on the following lines.
+			raw := rest[1:]
+			for {
+				if end := closingQuote(raw); end >= 0 {
+					raw = raw[:end]
+					break
+				}
+				i++
+				if i == len(lines) {
+					return nil, fmt.Errorf("line %d: unterminated double-quoted value", lineNo)
+				}
+				raw += "\n" + lines[i]
+			}
+			value = unescapeDotenv(raw)
+		default:
+			value = rest
+			if j := strings.Index(value, " #"); j >= 0 {
+				value = value[:j]
+			}
+			if j := strings.Index(value, "\t#"); j >= 0 {
+				value = value[:j]
+			}
+			value = strings.TrimSpace(value)
+		}
+
+		vars[key] = value
+	}
+
+	return vars, nil
+}
+
+// closingQuote returns the index of the first unescaped double quote in s, or
+// -1 if there is none.
+func closingQuote(s string) int {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+func unescapeDotenv(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case '"', '\\':
+			b.WriteByte(s[i])
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
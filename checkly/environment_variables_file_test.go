@@ -0,0 +1,114 @@
+package checkly
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseDotenv(t *testing.T) {
+	t.Parallel()
+
+	input := strings.Join([]string{
+		"# Checkly environment variables",
+		"",
+		"API_URL=https://api.example.com",
+		"export REGION = eu-west-1",
+		"GREETING='Hello # world'",
+		`MESSAGE="line one\nline two \"quoted\""`,
+		`CERT="-----BEGIN-----`,
+		`abc`,
+		`-----END-----"`,
+		"TIMEOUT=30 # seconds",
+		"EMPTY=",
+		"HASH=a#b",
+	}, "\r\n")
+
+	got, err := parseDotenv(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"API_URL":  "https://api.example.com",
+		"REGION":   "eu-west-1",
+		"GREETING": "Hello # world",
+		"MESSAGE":  "line one\nline two \"quoted\"",
+		"CERT":     "-----BEGIN-----\nabc\n-----END-----",
+		"TIMEOUT":  "30",
+		"EMPTY":    "",
+		"HASH":     "a#b",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("parseDotenv() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseDotenvErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "missing separator", input: "A=1\nAPI_URL", wantErr: "line 2: expected KEY=value"},
+		{name: "invalid key", input: "1KEY=value", wantErr: `line 1: invalid key "1KEY"`},
+		{name: "duplicate key", input: "A=1\nA=2", wantErr: `line 2: duplicate key "A"`},
+		{name: "unterminated single quote", input: "A='value", wantErr: "line 1: unterminated single-quoted value"},
+		{name: "unterminated double quote", input: "A=\"value\nB=2", wantErr: "line 1: unterminated double-quoted value"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseDotenv(c.input)
+			if err == nil || err.Error() != c.wantErr {
+				t.Errorf("parseDotenv() error = %v, want %q", err, c.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadEnvironmentVariablesFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	jsonPath := filepath.Join(dir, "variables.json")
+	if err := os.WriteFile(jsonPath, []byte(`{"API_URL": "https://api.example.com", "RETRIES": "3"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := readEnvironmentVariablesFile(jsonPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"API_URL": "https://api.example.com", "RETRIES": "3"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("readEnvironmentVariablesFile() mismatch (-want +got):\n%s", diff)
+	}
+
+	invalidPath := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalidPath, []byte(`{"RETRIES": 3}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readEnvironmentVariablesFile(invalidPath); err == nil || !strings.Contains(err.Error(), "must be a JSON object of strings") {
+		t.Errorf("readEnvironmentVariablesFile() error = %v, want a JSON object error", err)
+	}
+
+	dotenvPath := filepath.Join(dir, ".env")
+	if err := os.WriteFile(dotenvPath, []byte("API_URL=https://api.example.com\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err = readEnvironmentVariablesFile(dotenvPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]string{"API_URL": "https://api.example.com"}, got); diff != "" {
+		t.Errorf("readEnvironmentVariablesFile() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -13,17 +13,45 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func apiCallTimeout() time.Duration {
-	timeout := os.Getenv("API_CALL_TIMEOUT")
-	if timeout != "" {
-		v, err := strconv.ParseInt(timeout, 10, 64)
+const (
+	defaultRequestTimeout = 15 * time.Second
+
+	defaultCreateTimeout = 10 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 10 * time.Minute
+	defaultDeleteTimeout = 10 * time.Minute
+)
+
+// resourceTimeouts returns the default operation timeouts of a resource that
+// supports every operation. Each operation may span several API requests,
+// which are individually bounded by the request_timeout provider setting.
+func resourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultCreateTimeout),
+		Read:   schema.DefaultTimeout(defaultReadTimeout),
+		Update: schema.DefaultTimeout(defaultUpdateTimeout),
+		Delete: schema.DefaultTimeout(defaultDeleteTimeout),
+	}
+}
+
+// requestTimeoutDefault returns the default of the request_timeout provider
+// setting in seconds. API_CALL_TIMEOUT is still honored for compatibility.
+func requestTimeoutDefault() (any, error) {
+	for _, name := range []string{"CHECKLY_REQUEST_TIMEOUT", "API_CALL_TIMEOUT"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+
+		v, err := strconv.Atoi(value)
 		if err != nil || v < 1 {
-			panic("Invalid API_CALL_TIMEOUT value, must be a positive number")
-		} else {
-			return time.Duration(v) * time.Second
+			return nil, fmt.Errorf("invalid %s value %q, must be a positive number of seconds", name, value)
 		}
+
+		return v, nil
 	}
-	return 15 * time.Second
+
+	return int(defaultRequestTimeout / time.Second), nil
 }
 
 func checksumSha256(r io.Reader) string {
@@ -116,3 +144,13 @@ func optionalStringPointerFromResourceData(d *schema.ResourceData, key string) *
 	str := value.(string)
 	return &str
 }
+
+func stringsFromList(l []any) []string {
+	r := make([]string, 0, len(l))
+	for _, item := range l {
+		// Empty list elements arrive as nil.
+		s, _ := item.(string)
+		r = append(r, s)
+	}
+	return r
+}
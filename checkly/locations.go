@@ -0,0 +1,152 @@
+package checkly
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// locationInfo is a public location as listed by the API.
+type locationInfo struct {
+	ID   string `json:"region"`
+	Name string `json:"name"`
+
+	// IPv6Support is not part of the listing; see locationCatalog.load.
+	IPv6Support bool `json:"-"`
+}
+
+// locationContinents maps the prefix of a location ID to its continent.
+var locationContinents = map[string]string{
+	"af": "Africa",
+	"ap": "Asia Pacific",
+	"ca": "North America",
+	"eu": "Europe",
+	"me": "Middle East",
+	"sa": "South America",
+	"us": "North America",
+}
+
+// region returns the part of the location ID that names the region, such as
+// "eu-central" for "eu-central-1".
+func (l locationInfo) region() string {
+	i := strings.LastIndex(l.ID, "-")
+	if i < 0 {
+		return l.ID
+	}
+	return l.ID[:i]
+}
+
+// continent returns the continent of the location, or "" if it is unknown.
+func (l locationInfo) continent() string {
+	prefix, _, _ := strings.Cut(l.ID, "-")
+	return locationContinents[prefix]
+}
+
+// locationCatalog holds the public locations. Like runtimeCatalog, they are
+// listed once per provider instance, so that a plan with hundreds of checks
+// makes a single request. Failed lookups are not cached.
+type locationCatalog struct {
+	mu        sync.Mutex
+	loaded    bool
+	locations []locationInfo
+}
+
+// load returns the public locations.
+func (c *locationCatalog) load(ctx context.Context, api *apiClient) ([]locationInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.loaded {
+		return c.locations, nil
+	}
+
+	var locations []locationInfo
+	if err := api.get(ctx, "/v1/locations", nil, &locations); err != nil {
+		return nil, fmt.Errorf("failed to list locations: %w", err)
+	}
+
+	// Locations that support IPv6 are those with static IPv6 addresses.
+	// IPv6 support is only informational, so a failed lookup leaves every
+	// location without it instead of failing the validation of locations.
+	var ipv6 map[string][]string
+	if err := api.get(ctx, "/v1/static-ipv6s-by-region", nil, &ipv6); err != nil {
+		tflog.Warn(ctx, "Failed to list static IPv6 addresses; locations are listed without IPv6 support", map[string]any{
+			"error": err.Error(),
+		})
+		ipv6 = nil
+	}
+	for i := range locations {
+		locations[i].IPv6Support = len(ipv6[locations[i].ID]) > 0
+	}
+
+	c.locations, c.loaded = locations, true
+
+	return locations, nil
+}
+
+// validateLocations checks that every one of locations exists.
+func validateLocations(available []locationInfo, key string, locations []string) error {
+	var unknown []string
+	for _, l := range locations {
+		if !slices.ContainsFunc(available, func(a locationInfo) bool { return a.ID == l }) {
+			unknown = append(unknown, l)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(available))
+	for i, l := range available {
+		ids[i] = l.ID
+	}
+	slices.Sort(ids)
+
+	noun := "location"
+	if len(unknown) > 1 {
+		noun = "locations"
+	}
+	return fmt.Errorf("%s: unknown %s %s; available locations are %s", key, noun, strings.Join(unknown, ", "), strings.Join(ids, ", "))
+}
+
+// LocationsCustomizeDiff validates the locations of a check, monitor or group
+// at plan time.
+var LocationsCustomizeDiff = makeLocationsCustomizeDiff("locations")
+
+// makeLocationsCustomizeDiff returns a CustomizeDiffFunc that validates the
+// locations set by key against the public locations. They are validated when
+// the resource is created and when they change, so that unrelated changes
+// don't fail once a location is retired.
+func makeLocationsCustomizeDiff(key string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, meta any) error {
+		if diff.Id() != "" && !diff.HasChange(key) {
+			return nil
+		}
+		if !diff.NewValueKnown(key) {
+			return nil
+		}
+
+		v, ok := diff.GetOk(key)
+		if !ok {
+			return nil
+		}
+		locations := stringsFromSet(v.(*schema.Set))
+
+		m, ok := meta.(*providerMeta)
+		if !ok || m.api == nil {
+			return nil
+		}
+
+		available, err := m.locations.load(ctx, m.api)
+		if err != nil {
+			return err
+		}
+
+		return validateLocations(available, key, locations)
+	}
+}
@@ -0,0 +1,133 @@
+package checkly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestLocationInfoRegion(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		id        string
+		region    string
+		continent string
+	}{
+		{"eu-central-1", "eu-central", "Europe"},
+		{"ap-southeast-2", "ap-southeast", "Asia Pacific"},
+		{"ca-central-1", "ca-central", "North America"},
+		{"local", "local", ""},
+	}
+
+	for _, c := range cases {
+		l := locationInfo{ID: c.id}
+		if got := l.region(); got != c.region {
+			t.Errorf("region() of %q = %q, want %q", c.id, got, c.region)
+		}
+		if got := l.continent(); got != c.continent {
+			t.Errorf("continent() of %q = %q, want %q", c.id, got, c.continent)
+		}
+	}
+}
+
+func TestValidateLocations(t *testing.T) {
+	t.Parallel()
+
+	available := []locationInfo{{ID: "us-east-1"}, {ID: "eu-west-1"}, {ID: "eu-central-1"}}
+
+	if err := validateLocations(available, "locations", []string{"eu-central-1", "us-east-1"}); err != nil {
+		t.Errorf("validateLocations failed: %v", err)
+	}
+
+	err := validateLocations(available, "locations", []string{"eu-central-1", "eu-centrl-1"})
+	want := "locations: unknown location eu-centrl-1; available locations are eu-central-1, eu-west-1, us-east-1"
+	if err == nil || err.Error() != want {
+		t.Errorf("validateLocations error = %v, want %q", err, want)
+	}
+}
+
+func TestLocationCatalogLoad(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+	api := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/v1/locations":
+			json.NewEncoder(w).Encode([]map[string]string{
+				{"region": "eu-central-1", "name": "Frankfurt"},
+				{"region": "us-east-1", "name": "N. Virginia"},
+			})
+		case "/v1/static-ipv6s-by-region":
+			json.NewEncoder(w).Encode(map[string][]string{
+				"us-east-1": {"2600:1f18::/56"},
+			})
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	var catalog locationCatalog
+	for range 3 {
+		locations, err := catalog.load(context.Background(), api)
+		if err != nil {
+			t.Fatalf("load failed: %v", err)
+		}
+		want := []locationInfo{
+			{ID: "eu-central-1", Name: "Frankfurt"},
+			{ID: "us-east-1", Name: "N. Virginia", IPv6Support: true},
+		}
+		if len(locations) != len(want) || locations[0] != want[0] || locations[1] != want[1] {
+			t.Errorf("load = %+v, want %+v", locations, want)
+		}
+	}
+
+	if requests != 2 {
+		t.Errorf("load made %d requests, want 2", requests)
+	}
+}
+
+func TestLocationCatalogLoadIgnoresIPv6Errors(t *testing.T) {
+	t.Parallel()
+
+	responses := map[string]func(w http.ResponseWriter){
+		"server error": func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusInternalServerError)
+		},
+		"not found": func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusNotFound)
+		},
+		"unexpected shape": func(w http.ResponseWriter) {
+			json.NewEncoder(w).Encode([]map[string]string{{"region": "us-east-1", "ipv6": "2600:1f18::/56"}})
+		},
+	}
+
+	for name, respond := range responses {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			api := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/v1/locations":
+					json.NewEncoder(w).Encode([]map[string]string{{"region": "us-east-1", "name": "N. Virginia"}})
+				case "/v1/static-ipv6s-by-region":
+					respond(w)
+				default:
+					t.Errorf("unexpected request %s", r.URL)
+				}
+			})
+
+			var catalog locationCatalog
+			locations, err := catalog.load(context.Background(), api)
+			if err != nil {
+				t.Fatalf("load failed: %v", err)
+			}
+			want := locationInfo{ID: "us-east-1", Name: "N. Virginia"}
+			if len(locations) != 1 || locations[0] != want {
+				t.Errorf("load = %+v, want %+v", locations, want)
+			}
+		})
+	}
+}
@@ -2,10 +2,12 @@ package checkly
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"regexp"
+	"sort"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -242,13 +244,109 @@ func normalizeJSONC(b []byte) []byte {
 	return out
 }
 
-var yarnVersionRegexp = regexp.MustCompile(`^version[:\s]+["']?([^"'\s]+)["']?`)
+var (
+	yarnVersionRegexp = regexp.MustCompile(`^version[:\s]+["']?([^"'\s]+)["']?`)
+
+	// yarnBerryRegexp matches lines that only appear in Berry (v2+)
+	// lockfiles. Classic lockfiles use `resolved "<url>"` instead of
+	// `resolution:` and have no __metadata block.
+	yarnBerryRegexp = regexp.MustCompile(`(?m)^(?:__metadata:|\s+resolution:)`)
+)
+
+// isYarnBerryLock reports whether data is a Berry (v2+) yarn.lock.
+func isYarnBerryLock(data []byte) bool {
+	return yarnBerryRegexp.Match(data)
+}
 
 // extractPackageVersionFromYarnLock extracts the version of the given package
 // from a yarn.lock file. Handles both classic (v1) and berry (v2+) formats.
 // Workspace layouts don't affect yarn.lock structure — all resolved packages
 // are listed in the same flat structure.
 func extractPackageVersionFromYarnLock(r io.Reader, packageName string) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to read yarn.lock: %w", err)
+	}
+
+	if isYarnBerryLock(data) {
+		return extractPackageVersionFromYarnBerryLock(data, packageName)
+	}
+
+	return extractPackageVersionFromYarnClassicLock(bytes.NewReader(data), packageName)
+}
+
+// extractPackageVersionFromYarnBerryLock extracts the version of the given
+// package from a Berry yarn.lock, which is a YAML document. Entries are keyed
+// by one or more comma-separated descriptors, so they are matched on their
+// `resolution` instead. Only registry (`npm:`) and patched (`patch:`)
+// resolutions carry a usable version; workspace, portal, link and other
+// local protocols are skipped. When several versions of the package are
+// locked, the entries are considered in key order and the first one wins.
+func extractPackageVersionFromYarnBerryLock(data []byte, packageName string) (string, error) {
+	var lockfile map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &lockfile); err != nil {
+		return "", fmt.Errorf("failed to parse yarn.lock: %w", err)
+	}
+
+	keys := make([]string, 0, len(lockfile))
+	for key := range lockfile {
+		if key != "__metadata" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		node := lockfile[key]
+
+		var entry struct {
+			Version    string `yaml:"version"`
+			Resolution string `yaml:"resolution"`
+		}
+		if err := node.Decode(&entry); err != nil {
+			return "", fmt.Errorf("failed to parse yarn.lock entry %q: %w", key, err)
+		}
+
+		name, protocol := parseYarnBerryLocator(entry.Resolution)
+		if name != packageName {
+			continue
+		}
+
+		switch protocol {
+		case "npm", "patch":
+			if entry.Version != "" {
+				return entry.Version, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// parseYarnBerryLocator splits a Berry locator such as
+// "@playwright/test@npm:1.58.2" into the package name and the protocol.
+func parseYarnBerryLocator(locator string) (name string, protocol string) {
+	// Skip the first character so that the scope marker of a scoped package
+	// isn't mistaken for the name separator.
+	i := strings.IndexByte(locator[min(1, len(locator)):], '@')
+	if i < 0 {
+		return locator, ""
+	}
+	i++
+
+	name, reference := locator[:i], locator[i+1:]
+
+	protocol, _, ok := strings.Cut(reference, ":")
+	if !ok {
+		return name, ""
+	}
+
+	return name, protocol
+}
+
+// extractPackageVersionFromYarnClassicLock extracts the version of the given
+// package from a classic (v1) yarn.lock, a line-based format.
+func extractPackageVersionFromYarnClassicLock(r io.Reader, packageName string) (string, error) {
 	scanner := bufio.NewScanner(r)
 
 	inBlock := false
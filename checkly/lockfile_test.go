@@ -432,6 +432,90 @@ func TestExtractPackageVersionFromYarnLock(t *testing.T) {
 `,
 			want: "1.48.0",
 		},
+		{
+			name:        "berry format with metadata and multiple descriptors",
+			packageName: "@playwright/test",
+			input: `# This file is generated by running "yarn install" inside your project.
+# Manual changes might be lost - proceed with caution!
+
+__metadata:
+  version: 8
+  cacheKey: 10c0
+
+"@playwright/test@npm:1.58.2, @playwright/test@npm:^1.58.0":
+  version: 1.58.2
+  resolution: "@playwright/test@npm:1.58.2"
+  dependencies:
+    playwright: "npm:1.58.2"
+  bin:
+    playwright: cli.js
+  checksum: 10c0/abc
+  languageName: node
+  linkType: hard
+
+"app@workspace:.":
+  version: 0.0.0-use.local
+  resolution: "app@workspace:."
+  dependencies:
+    "@playwright/test": "npm:^1.58.0"
+  languageName: unknown
+  linkType: soft
+`,
+			want: "1.58.2",
+		},
+		{
+			name:        "berry format skips workspace entries",
+			packageName: "@playwright/test",
+			input: `__metadata:
+  version: 8
+  cacheKey: 10c0
+
+"@playwright/test@workspace:packages/playwright-test":
+  version: 0.0.0-use.local
+  resolution: "@playwright/test@workspace:packages/playwright-test"
+  languageName: unknown
+  linkType: soft
+`,
+			want: "",
+		},
+		{
+			name:        "berry format with patch protocol",
+			packageName: "@playwright/test",
+			input: `__metadata:
+  version: 8
+  cacheKey: 10c0
+
+"@playwright/test@patch:@playwright/test@npm%3A1.57.0#~/.yarn/patches/@playwright-test-npm-1.57.0-abc.patch":
+  version: 1.57.0
+  resolution: "@playwright/test@patch:@playwright/test@npm%3A1.57.0#~/.yarn/patches/@playwright-test-npm-1.57.0-abc.patch::version=1.57.0&hash=def"
+  languageName: node
+  linkType: hard
+`,
+			want: "1.57.0",
+		},
+		{
+			name:        "berry format with unquoted key",
+			packageName: "typescript",
+			input: `__metadata:
+  version: 8
+  cacheKey: 10c0
+
+typescript@npm:^5.3.0:
+  version: 5.3.2
+  resolution: "typescript@npm:5.3.2"
+`,
+			want: "5.3.2",
+		},
+		{
+			name:        "berry format with invalid yaml",
+			packageName: "@playwright/test",
+			input: `__metadata:
+  version: 8
+"@playwright/test@npm:1.58.2":
+  version: [1.58.2
+`,
+			wantErr: true,
+		},
 		{
 			name:        "different package",
 			packageName: "typescript",
@@ -477,3 +561,30 @@ func TestExtractPackageVersionFromYarnLock(t *testing.T) {
 		})
 	}
 }
+
+func TestParseYarnBerryLocator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		locator      string
+		wantName     string
+		wantProtocol string
+	}{
+		{"@playwright/test@npm:1.58.2", "@playwright/test", "npm"},
+		{"typescript@npm:5.3.2", "typescript", "npm"},
+		{"app@workspace:.", "app", "workspace"},
+		{"@playwright/test@patch:@playwright/test@npm%3A1.57.0#~/x.patch", "@playwright/test", "patch"},
+		{"typescript", "typescript", ""},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locator, func(t *testing.T) {
+			t.Parallel()
+			name, protocol := parseYarnBerryLocator(tt.locator)
+			if name != tt.wantName || protocol != tt.wantProtocol {
+				t.Errorf("got (%q, %q), want (%q, %q)", name, protocol, tt.wantName, tt.wantProtocol)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package checkly
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// privateLocationMetricsWindow is how far back the queue size of a private
+// location is looked up.
+const privateLocationMetricsWindow = 5 * time.Minute
+
+// privateLocationStatus is the agent status of a private location as
+// returned by the API.
+type privateLocationStatus struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	SlugName   string `json:"slugName"`
+	AgentCount int    `json:"agentCount"`
+	LastSeen   string `json:"lastSeen"`
+
+	// QueueSize and OldestScheduledCheckRun come from the metrics of the
+	// location, if there are any.
+	QueueSize               int    `json:"-"`
+	OldestScheduledCheckRun string `json:"-"`
+}
+
+// healthy reports whether agents are connected to the location.
+func (s privateLocationStatus) healthy() bool {
+	return s.AgentCount > 0
+}
+
+// privateLocationMetrics are the metrics of a private location, as series
+// that share their timestamps.
+type privateLocationMetrics struct {
+	Timestamps              []string `json:"timestamps"`
+	QueueSize               []int    `json:"queueSize"`
+	OldestScheduledCheckRun []string `json:"oldestScheduledCheckRun"`
+}
+
+// fetchPrivateLocationStatus returns the agent status of the private location
+// with the given ID, along with the latest queue size.
+func fetchPrivateLocationStatus(ctx context.Context, api *apiClient, id string, now time.Time) (privateLocationStatus, error) {
+	path := "/v1/private-locations/" + url.PathEscape(id)
+
+	var status privateLocationStatus
+	if err := api.get(ctx, path, nil, &status); err != nil {
+		return privateLocationStatus{}, fmt.Errorf("failed to retrieve private location %q: %w", id, err)
+	}
+
+	query := url.Values{}
+	query.Set("from", strconv.FormatInt(now.Add(-privateLocationMetricsWindow).Unix(), 10))
+	query.Set("to", strconv.FormatInt(now.Unix(), 10))
+
+	// Locations without recent metrics are reported with an empty queue.
+	var metrics privateLocationMetrics
+	if err := api.get(ctx, path+"/metrics", query, &metrics); err != nil && !isNotFoundError(err) {
+		return privateLocationStatus{}, fmt.Errorf("failed to retrieve metrics of private location %q: %w", id, err)
+	}
+	if n := len(metrics.QueueSize); n > 0 {
+		status.QueueSize = metrics.QueueSize[n-1]
+	}
+	if n := len(metrics.OldestScheduledCheckRun); n > 0 {
+		status.OldestScheduledCheckRun = metrics.OldestScheduledCheckRun[n-1]
+	}
+
+	return status, nil
+}
+
+// privateLocationCatalog holds what the health warning of
+// checkly_private_location needs: the agent status of each private location,
+// and the checks and check groups of the account. Like runtimeCatalog, they
+// are looked up once per provider instance, so that refreshing many private
+// locations doesn't list every check for each of them. Failed lookups are not
+// cached.
+type privateLocationCatalog struct {
+	mu       sync.Mutex
+	statuses map[string]privateLocationStatus
+
+	checksLoaded bool
+	checks       []checkSummary
+	groups       []checkGroupSummary
+}
+
+// status returns the agent status of the private location with the given ID.
+func (c *privateLocationCatalog) status(ctx context.Context, api *apiClient, id string, now time.Time) (privateLocationStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if status, ok := c.statuses[id]; ok {
+		return status, nil
+	}
+
+	status, err := fetchPrivateLocationStatus(ctx, api, id, now)
+	if err != nil {
+		return privateLocationStatus{}, err
+	}
+
+	if c.statuses == nil {
+		c.statuses = make(map[string]privateLocationStatus)
+	}
+	c.statuses[id] = status
+
+	return status, nil
+}
+
+// checksIn returns the IDs of the checks that run in the private location with
+// the given slug name, either set on the check itself or inherited from its
+// group.
+func (c *privateLocationCatalog) checksIn(ctx context.Context, api *apiClient, slugName string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.checksLoaded {
+		checks, err := listAPIPages[checkSummary](ctx, api, "/v1/checks", nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list checks: %w", err)
+		}
+
+		// Groups are only listed when there are grouped checks.
+		var groups []checkGroupSummary
+		if slices.ContainsFunc(checks, func(c checkSummary) bool { return c.GroupID != 0 }) {
+			groups, err = listAPIPages[checkGroupSummary](ctx, api, "/v1/check-groups", nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list check groups: %w", err)
+			}
+		}
+
+		c.checks, c.groups, c.checksLoaded = checks, groups, true
+	}
+
+	groupsInLocation := make(map[int64]bool)
+	for _, g := range c.groups {
+		if slices.Contains(g.PrivateLocations, slugName) {
+			groupsInLocation[g.ID] = true
+		}
+	}
+
+	var ids []string
+	for _, check := range c.checks {
+		if slices.Contains(check.PrivateLocations, slugName) || groupsInLocation[check.GroupID] {
+			ids = append(ids, check.ID)
+		}
+	}
+	return ids, nil
+}
+
+// withPrivateLocationHealthWarning adds a warning to the result of f, the
+// read function of checkly_private_location, when require_healthy_agents is
+// set and checks run in the location while no agents are connected to it.
+// Since Terraform refreshes resources when planning, the warning shows up in
+// the plan, unless it is made with -refresh=false. Only checks that already
+// exist are taken into account, not those that are being planned.
+func withPrivateLocationHealthWarning(
+	f func(context.Context, *schema.ResourceData, any) diag.Diagnostics,
+) func(context.Context, *schema.ResourceData, any) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+		diags := f(ctx, d, meta)
+		if diags.HasError() || d.Id() == "" || !d.Get("require_healthy_agents").(bool) {
+			return diags
+		}
+
+		m, ok := meta.(*providerMeta)
+		if !ok || m.api == nil {
+			return diags
+		}
+
+		warning, err := privateLocationHealthWarning(ctx, &m.privateLocations, m.api, d.Id(), d.Get("slug_name").(string), time.Now())
+		if err != nil {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Failed to check the agents of the private location",
+				Detail:   err.Error(),
+			})
+		}
+		if warning != nil {
+			diags = append(diags, *warning)
+		}
+
+		return diags
+	}
+}
+
+// privateLocationHealthWarning returns a warning when checks run in the
+// private location, but no agents are connected to it.
+func privateLocationHealthWarning(ctx context.Context, catalog *privateLocationCatalog, api *apiClient, id, slugName string, now time.Time) (*diag.Diagnostic, error) {
+	status, err := catalog.status(ctx, api, id, now)
+	if err != nil || status.healthy() {
+		return nil, err
+	}
+
+	checks, err := catalog.checksIn(ctx, api, slugName)
+	if err != nil || len(checks) == 0 {
+		return nil, err
+	}
+
+	assigned := fmt.Sprintf("%d checks are", len(checks))
+	if len(checks) == 1 {
+		assigned = "1 check is"
+	}
+
+	detail := fmt.Sprintf("%s set to run in private location %q, but no agents are connected to it. Start an agent with a key of the location.", assigned, slugName)
+	if status.LastSeen != "" {
+		detail += fmt.Sprintf(" An agent was last seen at %s.", status.LastSeen)
+	}
+	if status.QueueSize > 0 {
+		detail += fmt.Sprintf(" %d check runs are queued.", status.QueueSize)
+	}
+
+	return &diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "Private location has no agents",
+		Detail:   detail,
+	}, nil
+}
@@ -0,0 +1,155 @@
+package checkly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newPrivateLocationStatusServer serves a private location with the given
+// agent count, a check that runs in it and a check that inherits it from its
+// group.
+func newPrivateLocationStatusServer(t *testing.T, agentCount int) *apiClient {
+	t.Helper()
+
+	return newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/private-locations":
+			json.NewEncoder(w).Encode([]privateLocationSummary{
+				{ID: "pl-1", Name: "Office", SlugName: "office"},
+				{ID: "pl-2", Name: "Data center", SlugName: "data-center"},
+			})
+		case "/v1/private-locations/pl-1":
+			json.NewEncoder(w).Encode(map[string]any{
+				"id":         "pl-1",
+				"name":       "Office",
+				"slugName":   "office",
+				"agentCount": agentCount,
+				"lastSeen":   "2026-10-17T08:00:00.000Z",
+			})
+		case "/v1/private-locations/pl-1/metrics":
+			if r.URL.Query().Get("to") == "" {
+				t.Errorf("metrics requested without a time range: %s", r.URL)
+			}
+			json.NewEncoder(w).Encode(privateLocationMetrics{
+				Timestamps:              []string{"2026-10-17T08:59:00.000Z", "2026-10-17T09:00:00.000Z"},
+				QueueSize:               []int{3, 7},
+				OldestScheduledCheckRun: []string{"2026-10-17T08:55:00.000Z", "2026-10-17T08:56:00.000Z"},
+			})
+		case "/v1/checks":
+			json.NewEncoder(w).Encode([]checkSummary{
+				{ID: "check-1", PrivateLocations: []string{"office"}},
+				{ID: "check-2", PrivateLocations: []string{"data-center"}},
+				{ID: "check-3", GroupID: 5},
+				{ID: "check-4", GroupID: 6},
+			})
+		case "/v1/check-groups":
+			json.NewEncoder(w).Encode([]checkGroupSummary{
+				{ID: 5, Name: "Office checks", PrivateLocations: []string{"office"}},
+				{ID: 6, Name: "Data center checks", PrivateLocations: []string{"data-center"}},
+			})
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			http.NotFound(w, r)
+		}
+	})
+}
+
+func TestDataSourcePrivateLocationStatusRead(t *testing.T) {
+	t.Parallel()
+
+	meta := &providerMeta{api: newPrivateLocationStatusServer(t, 2)}
+
+	d := dataSourcePrivateLocationStatus().TestResourceData()
+	d.Set("slug_name", "office")
+
+	if err := dataSourcePrivateLocationStatusRead(context.Background(), d, meta); err != nil {
+		t.Fatalf("dataSourcePrivateLocationStatusRead failed: %v", err)
+	}
+
+	want := map[string]any{
+		"private_location_id":        "pl-1",
+		"name":                       "Office",
+		"agent_count":                2,
+		"healthy":                    true,
+		"last_seen":                  "2026-10-17T08:00:00.000Z",
+		"queue_size":                 7,
+		"oldest_scheduled_check_run": "2026-10-17T08:56:00.000Z",
+	}
+	for k, v := range want {
+		if got := d.Get(k); got != v {
+			t.Errorf("%s = %v, want %v", k, got, v)
+		}
+	}
+}
+
+func TestPrivateLocationHealthWarning(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+
+	var catalog privateLocationCatalog
+	warning, err := privateLocationHealthWarning(context.Background(), &catalog, newPrivateLocationStatusServer(t, 0), "pl-1", "office", now)
+	if err != nil {
+		t.Fatalf("privateLocationHealthWarning failed: %v", err)
+	}
+	if warning == nil {
+		t.Fatal("privateLocationHealthWarning returned no warning for a location without agents")
+	}
+	if !strings.Contains(warning.Detail, `2 checks are set to run in private location "office"`) {
+		t.Errorf("unexpected warning detail %q", warning.Detail)
+	}
+
+	warning, err = privateLocationHealthWarning(context.Background(), &privateLocationCatalog{}, newPrivateLocationStatusServer(t, 1), "pl-1", "office", now)
+	if err != nil || warning != nil {
+		t.Errorf("privateLocationHealthWarning for a healthy location = %v, %v, want no warning", warning, err)
+	}
+}
+
+func TestPrivateLocationCatalogChecksIn(t *testing.T) {
+	t.Parallel()
+
+	requests := map[string]int{}
+	api := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		switch r.URL.Path {
+		case "/v1/checks":
+			json.NewEncoder(w).Encode([]checkSummary{
+				{ID: "check-1", PrivateLocations: []string{"office"}},
+				{ID: "check-2", GroupID: 5},
+			})
+		case "/v1/check-groups":
+			json.NewEncoder(w).Encode([]checkGroupSummary{
+				{ID: 5, PrivateLocations: []string{"data-center"}},
+			})
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			http.NotFound(w, r)
+		}
+	})
+
+	var catalog privateLocationCatalog
+	for _, tt := range []struct {
+		slug string
+		want string
+	}{
+		{"office", "check-1"},
+		{"data-center", "check-2"},
+		{"office", "check-1"},
+	} {
+		checks, err := catalog.checksIn(context.Background(), api, tt.slug)
+		if err != nil {
+			t.Fatalf("checksIn(%q) failed: %v", tt.slug, err)
+		}
+		if len(checks) != 1 || checks[0] != tt.want {
+			t.Errorf("checksIn(%q) = %v, want [%s]", tt.slug, checks, tt.want)
+		}
+	}
+
+	if requests["/v1/checks"] != 1 || requests["/v1/check-groups"] != 1 {
+		t.Errorf("checksIn made requests %v, want one of each", requests)
+	}
+}
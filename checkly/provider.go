@@ -1,10 +1,12 @@
 package checkly
 
 import (
-	"fmt"
-	"io"
+	"context"
+	"net/http"
 	"os"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/checkly/checkly-go-sdk"
@@ -16,18 +18,94 @@ func Provider() *schema.Provider {
 		Schema: map[string]*schema.Schema{
 			"api_key": {
 				Type:        schema.TypeString,
-				Required:    true,
-				DefaultFunc: schema.EnvDefaultFunc("CHECKLY_API_KEY", nil),
+				Optional:    true,
+				Description: "The Checkly API key. Required unless it is set by `profile`. Can also be set with the `CHECKLY_API_KEY` environment variable, which `profile` takes precedence over.",
 			},
 			"api_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"account_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"profile": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("CHECKLY_API_URL", nil),
+				DefaultFunc: schema.EnvDefaultFunc("CHECKLY_PROFILE", nil),
+				Description: "The name of a profile in `config_file` to take `api_key`, `account_id` and `api_url` from. Settings made directly take precedence over the profile, and the profile takes precedence over the `CHECKLY_API_KEY`, `CHECKLY_ACCOUNT_ID` and `CHECKLY_API_URL` environment variables. Use one provider alias per profile to manage several accounts. Can also be set with the `CHECKLY_PROFILE` environment variable.",
 			},
-			"account_id": {
+			"config_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("CHECKLY_ACCOUNT_ID", nil),
+				DefaultFunc: schema.EnvDefaultFunc("CHECKLY_CONFIG_FILE", defaultConfigFile),
+				Description: "Path to the JSON file holding the profiles, in the form `{\"profiles\": {\"<name>\": {\"api_key\": \"...\", \"account_id\": \"...\", \"api_url\": \"...\"}}}`. Can also be set with the `CHECKLY_CONFIG_FILE` environment variable. (Default `~/.checkly/config.json`).",
+			},
+			"skip_account_validation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CHECKLY_SKIP_ACCOUNT_VALIDATION", false),
+				Description: "Skip checking, when the provider is configured, that `api_key` is valid and belongs to `account_id`. Can also be set with the `CHECKLY_SKIP_ACCOUNT_VALIDATION` environment variable. (Default `false`).",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("CHECKLY_MAX_RETRIES", defaultMaxRetries),
+				Description:  "How many times an API request is retried when it is rate limited, or when an idempotent request fails with a transient error. Set to `0` to disable retries. Can also be set with the `CHECKLY_MAX_RETRIES` environment variable. (Default `3`).",
+				ValidateFunc: validateAtLeast(0),
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("CHECKLY_RETRY_MAX_WAIT", int(defaultRetryMaxWait/time.Second)),
+				Description:  "The longest time, in seconds, to wait before retrying an API request. A request whose `Retry-After` asks for longer is not retried. Can also be set with the `CHECKLY_RETRY_MAX_WAIT` environment variable. (Default `30`).",
+				ValidateFunc: validateAtLeast(1),
+			},
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  requestTimeoutDefault,
+				Description:  "How long, in seconds, a single API request may take before it is aborted. Each retry gets the full timeout. Code bundle uploads are only bounded by the `create` timeout of the resource. Can also be set with the `CHECKLY_REQUEST_TIMEOUT` environment variable, or the deprecated `API_CALL_TIMEOUT` environment variable. (Default `15`).",
+				ValidateFunc: validateAtLeast(1),
+			},
+			"default_tags": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tags": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "Tags to add to every check and monitor.",
+						},
+					},
+				},
+				Description: "Tags added to every check and monitor managed by this provider, on top of their own `tags`. Default tags are left out of `tags` when reading a check or monitor, so that they do not show up as drift, and are part of its computed `tags_all` attribute instead.",
+			},
+			"default_locations": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Locations to run checks and monitors in that set none of `locations`, `private_locations` and `group_id`.",
+			},
+			"engine_rules_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("CHECKLY_ENGINE_RULES_FILE", nil),
+				Description:   "Path to a JSON file with JavaScript runtime version rules, in the same format as the rules embedded in the provider. They are merged over the embedded rules and take precedence over them. Conflicts with `engine_rules`. Can also be set with the `CHECKLY_ENGINE_RULES_FILE` environment variable.",
+				ConflictsWith: []string{"engine_rules"},
+			},
+			"engine_rules": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "JavaScript runtime version rules as a JSON string, in the same format as `engine_rules_file`. Conflicts with `engine_rules_file`.",
+				ConflictsWith: []string{"engine_rules_file"},
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
@@ -45,7 +123,9 @@ func Provider() *schema.Provider {
 			"checkly_trigger_check":          resourceTriggerCheck(),
 			"checkly_trigger_group":          resourceTriggerGroup(),
 			"checkly_environment_variable":   resourceEnvironmentVariable(),
+			"checkly_environment_variables":  resourceEnvironmentVariables(),
 			"checkly_private_location":       resourcePrivateLocation(),
+			"checkly_private_location_key":   resourcePrivateLocationKey(),
 			"checkly_client_certificate":     resourceClientCertificate(),
 			"checkly_status_page":            resourceStatusPage(),
 			"checkly_status_page_service":    resourceStatusPageService(),
@@ -59,47 +139,86 @@ func Provider() *schema.Provider {
 			"checkly_playwright_code_bundle": resourcePlaywrightCodeBundle(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"checkly_static_ips": dataSourceStaticIPs(),
+			"checkly_static_ips":                   dataSourceStaticIPs(),
+			"checkly_checks":                       dataSourceChecks(),
+			"checkly_check_group":                  dataSourceCheckGroup(),
+			"checkly_alert_channel":                dataSourceAlertChannel(),
+			"checkly_private_location":             dataSourcePrivateLocation(),
+			"checkly_private_location_status":      dataSourcePrivateLocationStatus(),
+			"checkly_snippet":                      dataSourceSnippet(),
+			"checkly_runtimes":                     dataSourceRuntimes(),
+			"checkly_locations":                    dataSourceLocations(),
+			"checkly_playwright_bundle_inspection": dataSourcePlaywrightBundleInspection(),
 		},
-		ConfigureFunc: func(r *schema.ResourceData) (interface{}, error) {
-			debugLog := os.Getenv("CHECKLY_DEBUG_LOG")
-			var debugOutput io.Writer
-			if debugLog != "" {
-				debugFile, err := os.OpenFile(debugLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		ConfigureContextFunc: func(ctx context.Context, r *schema.ResourceData) (interface{}, diag.Diagnostics) {
+			var diags diag.Diagnostics
+
+			if os.Getenv("CHECKLY_DEBUG_LOG") != "" {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "CHECKLY_DEBUG_LOG is no longer supported",
+					Detail:   "Requests to the Checkly API are now logged through Terraform. Set TF_LOG_PROVIDER_CHECKLY_API=DEBUG to log them, or TRACE to include request and response bodies, and TF_LOG_PATH to write the log to a file.",
+				})
+			}
+
+			configured := checklyProfile{
+				APIKey:    r.Get("api_key").(string),
+				APIURL:    r.Get("api_url").(string),
+				AccountID: r.Get("account_id").(string),
+			}
+
+			var profile *checklyProfile
+			if profileName := r.Get("profile").(string); profileName != "" {
+				var err error
+				profile, err = loadChecklyProfile(r.Get("config_file").(string), profileName)
 				if err != nil {
-					panic(fmt.Sprintf("can't write to debug log file: %v", err))
+					return nil, append(diags, diag.FromErr(err)...)
 				}
-				debugOutput = debugFile
 			}
 
-			apiKey := ""
-			switch v := r.Get("api_key").(type) {
-			case string:
-				apiKey = v
-			}
+			settings := resolveChecklyProfile(configured, profile, checklyProfileFromEnv())
+			apiKey, apiUrl, accountId := settings.APIKey, settings.APIURL, settings.AccountID
 
-			apiUrl := ""
-			switch v := r.Get("api_url").(type) {
-			case string:
-				apiUrl = v
+			if apiKey == "" {
+				return nil, append(diags, diag.Errorf("api_key must be set, either directly, through the CHECKLY_API_KEY environment variable, or through a profile")...)
 			}
 
 			if apiUrl == "" {
-				apiUrl = "https://api.checklyhq.com"
+				apiUrl = defaultAPIURL
+			}
+
+			httpClient := &http.Client{
+				Transport: newRetryTransport(
+					newTimeoutTransport(
+						newLoggingTransport(http.DefaultTransport, apiKey),
+						time.Duration(r.Get("request_timeout").(int))*time.Second,
+					),
+					r.Get("max_retries").(int),
+					time.Duration(r.Get("retry_max_wait").(int))*time.Second,
+				),
+			}
+
+			api := &apiClient{
+				httpClient: httpClient,
+				baseURL:    apiUrl,
+				apiKey:     apiKey,
+				accountID:  accountId,
+			}
+
+			if !r.Get("skip_account_validation").(bool) {
+				err := validateAccount(ctx, api)
+				if err != nil {
+					return nil, append(diags, diag.FromErr(err)...)
+				}
 			}
 
 			client := checkly.NewClient(
 				apiUrl,
 				apiKey,
+				httpClient,
 				nil,
-				debugOutput,
 			)
 
-			accountId := ""
-			switch v := r.Get("account_id").(type) {
-			case string:
-				accountId = v
-			}
 			if accountId != "" {
 				client.SetAccountId(accountId)
 			}
@@ -111,7 +230,20 @@ func Provider() *schema.Provider {
 				client.SetChecklySource("TF")
 			}
 
-			return client, nil
+			engineRuleSet, err := loadEngineRuleSet(
+				r.Get("engine_rules_file").(string),
+				r.Get("engine_rules").(string),
+			)
+			if err != nil {
+				return nil, append(diags, diag.FromErr(err)...)
+			}
+
+			return &providerMeta{
+				Client:      client,
+				api:         api,
+				defaults:    providerDefaultsFromResourceData(r),
+				engineRules: engineRuleSet,
+			}, diags
 		},
 	}
 }
@@ -0,0 +1,139 @@
+package checkly
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+// providerMeta is what the provider hands to resources and data sources. It
+// embeds the client, so that meta can be used as a checkly.Client directly,
+// and carries the provider-wide defaults along with it.
+type providerMeta struct {
+	checkly.Client
+
+	// api covers the endpoints the client does not.
+	api *apiClient
+
+	defaults providerDefaults
+
+	// runtimes are listed once, when a runtime is first validated, and the
+	// default runtime of each check group once, when a check in it is.
+	runtimes runtimeCatalog
+
+	// locations are listed once, when locations are first validated.
+	locations locationCatalog
+
+	// privateLocations holds the agent status and the checks that the health
+	// warning of checkly_private_location looks up once.
+	privateLocations privateLocationCatalog
+
+	// engineRules are the engine rules of this provider configuration.
+	engineRules *engineRuleSet
+}
+
+// providerDefaults are the settings of the provider that apply to every check
+// and monitor.
+type providerDefaults struct {
+	// Tags are added to the tags of every check and monitor.
+	Tags []string
+
+	// Locations are used by checks and monitors that set no locations,
+	// private locations or group of their own.
+	Locations []string
+}
+
+func providerDefaultsFromResourceData(r *schema.ResourceData) providerDefaults {
+	var defaults providerDefaults
+
+	if v := r.Get("default_tags").([]any); len(v) > 0 && v[0] != nil {
+		defaults.Tags = stringsFromSet(v[0].(tfMap)["tags"].(*schema.Set))
+	}
+
+	defaults.Locations = stringsFromSet(r.Get("default_locations").(*schema.Set))
+
+	return defaults
+}
+
+// providerDefaultsFromMeta returns the defaults carried by meta. A bare
+// client, as used in unit tests, carries none.
+func providerDefaultsFromMeta(meta any) providerDefaults {
+	if m, ok := meta.(*providerMeta); ok {
+		return m.defaults
+	}
+	return providerDefaults{}
+}
+
+// mergeTags returns tags with the default tags added, sorted and without
+// duplicates.
+func (p providerDefaults) mergeTags(tags []string) []string {
+	merged := slices.Concat(tags, p.Tags)
+	sort.Strings(merged)
+	return slices.Compact(merged)
+}
+
+// locationsFor returns the locations to send for a check or monitor. The
+// default locations are only used when the check sets no locations, private
+// locations or group, each of which would otherwise decide where it runs.
+func (p providerDefaults) locationsFor(locations []string, privateLocations *[]string, groupID int64) []string {
+	if len(locations) > 0 || (privateLocations != nil && len(*privateLocations) > 0) || groupID != 0 {
+		return locations
+	}
+	if len(p.Locations) == 0 {
+		return locations
+	}
+	return slices.Clone(p.Locations)
+}
+
+// storeWithProviderDefaults runs store, which writes an API model to d, and
+// then removes what the provider defaults added from tags and locations, so
+// that they do not show up as drift against the configuration. The tags as
+// returned by the API are kept in tags_all.
+//
+// A default tag is only removed if it was not part of the tags before, since
+// a check may well set a tag that is also a default tag. Likewise, locations
+// are only cleared if there were none before and the API returned exactly the
+// default locations.
+func storeWithProviderDefaults(d *schema.ResourceData, meta any, store func() error) error {
+	defaults := providerDefaultsFromMeta(meta)
+
+	priorTags := stringsFromSet(d.Get("tags").(*schema.Set))
+
+	// Not every resource has locations.
+	priorLocations, hasLocations := d.Get("locations").(*schema.Set)
+
+	if err := store(); err != nil {
+		return err
+	}
+
+	tags := stringsFromSet(d.Get("tags").(*schema.Set))
+	sort.Strings(tags)
+	d.Set(tagsAllAttributeName, tags)
+
+	tags = slices.DeleteFunc(tags, func(tag string) bool {
+		return slices.Contains(defaults.Tags, tag) && !slices.Contains(priorTags, tag)
+	})
+	d.Set("tags", tags)
+
+	if !hasLocations || priorLocations.Len() > 0 || len(defaults.Locations) == 0 {
+		return nil
+	}
+
+	locations := stringsFromSet(d.Get("locations").(*schema.Set))
+	if sameStrings(locations, defaults.Locations) {
+		d.Set("locations", []string{})
+	}
+
+	return nil
+}
+
+// sameStrings reports whether a and b hold the same strings, in any order.
+func sameStrings(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	sort.Strings(a)
+	sort.Strings(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
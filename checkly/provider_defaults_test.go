@@ -0,0 +1,161 @@
+package checkly
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestProviderDefaultsLocationsFor(t *testing.T) {
+	t.Parallel()
+
+	defaults := providerDefaults{Locations: []string{"eu-west-1", "us-east-1"}}
+
+	tests := []struct {
+		name             string
+		locations        []string
+		privateLocations []string
+		groupID          int64
+		want             []string
+	}{
+		{name: "no locations", want: []string{"eu-west-1", "us-east-1"}},
+		{name: "own locations", locations: []string{"ap-south-1"}, want: []string{"ap-south-1"}},
+		{name: "private locations", privateLocations: []string{"office"}},
+		{name: "group", groupID: 42},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := defaults.locationsFor(tt.locations, &tt.privateLocations, tt.groupID)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("locationsFor() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProviderDefaultsMergeTags(t *testing.T) {
+	t.Parallel()
+
+	defaults := providerDefaults{Tags: []string{"team:payments", "managed-by:terraform"}}
+
+	got := defaults.mergeTags([]string{"api", "team:payments"})
+	want := []string{"api", "managed-by:terraform", "team:payments"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mergeTags() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestStoreWithProviderDefaults(t *testing.T) {
+	t.Parallel()
+
+	meta := &providerMeta{
+		defaults: providerDefaults{
+			Tags:      []string{"team:payments", "managed-by:terraform"},
+			Locations: []string{"eu-west-1", "us-east-1"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		resource       *schema.Resource
+		priorTags      []string
+		priorLocations []string
+		apiTags        []string
+		apiLocations   []string
+		wantTags       []string
+		wantTagsAll    []string
+		wantLocations  []string
+	}{
+		{
+			name:          "defaults are left out",
+			resource:      resourceURLMonitor(),
+			priorTags:     []string{"api"},
+			apiTags:       []string{"api", "managed-by:terraform", "team:payments"},
+			apiLocations:  []string{"us-east-1", "eu-west-1"},
+			wantTags:      []string{"api"},
+			wantTagsAll:   []string{"api", "managed-by:terraform", "team:payments"},
+			wantLocations: []string{},
+		},
+		{
+			name:          "default tag set by the check",
+			resource:      resourceURLMonitor(),
+			priorTags:     []string{"team:payments"},
+			apiTags:       []string{"managed-by:terraform", "team:payments"},
+			apiLocations:  []string{"eu-west-1", "us-east-1"},
+			wantTags:      []string{"team:payments"},
+			wantTagsAll:   []string{"managed-by:terraform", "team:payments"},
+			wantLocations: []string{},
+		},
+		{
+			name:           "own locations are kept",
+			resource:       resourceURLMonitor(),
+			priorLocations: []string{"eu-west-1", "us-east-1"},
+			apiTags:        []string{"managed-by:terraform", "team:payments"},
+			apiLocations:   []string{"eu-west-1", "us-east-1"},
+			wantTags:       []string{},
+			wantTagsAll:    []string{"managed-by:terraform", "team:payments"},
+			wantLocations:  []string{"eu-west-1", "us-east-1"},
+		},
+		{
+			name:          "outdated default locations show up",
+			resource:      resourceURLMonitor(),
+			apiLocations:  []string{"eu-west-1"},
+			wantTags:      []string{},
+			wantTagsAll:   []string{},
+			wantLocations: []string{"eu-west-1"},
+		},
+		{
+			name:        "resource without locations",
+			resource:    resourceHeartbeatMonitor(),
+			apiTags:     []string{"managed-by:terraform", "team:payments", "cron"},
+			wantTags:    []string{"cron"},
+			wantTagsAll: []string{"cron", "managed-by:terraform", "team:payments"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := tt.resource.TestResourceData()
+			d.Set("tags", tt.priorTags)
+			if tt.priorLocations != nil {
+				d.Set("locations", tt.priorLocations)
+			}
+
+			err := storeWithProviderDefaults(d, meta, func() error {
+				d.Set("tags", tt.apiTags)
+				if tt.apiLocations != nil {
+					d.Set("locations", tt.apiLocations)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("storeWithProviderDefaults failed: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.wantTags, sortedStringsFromSet(d, "tags")); diff != "" {
+				t.Errorf("tags mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantTagsAll, sortedStringsFromSet(d, tagsAllAttributeName)); diff != "" {
+				t.Errorf("tags_all mismatch (-want +got):\n%s", diff)
+			}
+			if tt.wantLocations != nil {
+				if diff := cmp.Diff(tt.wantLocations, sortedStringsFromSet(d, "locations")); diff != "" {
+					t.Errorf("locations mismatch (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func sortedStringsFromSet(d *schema.ResourceData, key string) []string {
+	s := stringsFromSet(d.Get(key).(*schema.Set))
+	slices.Sort(s)
+	return s
+}
@@ -0,0 +1,163 @@
+package checkly
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	defaultAPIURL = "https://api.checklyhq.com"
+
+	// defaultConfigFile is where profiles are looked up unless config_file is
+	// set. A leading "~" stands for the home directory.
+	defaultConfigFile = "~/.checkly/config.json"
+)
+
+// checklyProfile holds the credentials of one named profile in the config
+// file. Empty values are left to the provider configuration.
+type checklyProfile struct {
+	APIKey    string `json:"api_key"`
+	AccountID string `json:"account_id"`
+	APIURL    string `json:"api_url"`
+}
+
+// checklyConfigFile is the layout of the config file:
+//
+//	{
+//	  "profiles": {
+//	    "staging": {"api_key": "...", "account_id": "..."},
+//	    "production": {"api_key": "...", "account_id": "...", "api_url": "..."}
+//	  }
+//	}
+type checklyConfigFile struct {
+	Profiles map[string]checklyProfile `json:"profiles"`
+}
+
+// loadChecklyProfile reads the profile called name from file.
+func loadChecklyProfile(file, name string) (*checklyProfile, error) {
+	if file == "" {
+		file = defaultConfigFile
+	}
+
+	path, err := expandHomeDir(file)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file for profile %q: %w", name, err)
+	}
+
+	var config checklyConfigFile
+
+	dec := json.NewDecoder(bytes.NewReader(content))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %q: %w", path, err)
+	}
+
+	profile, ok := config.Profiles[name]
+	if !ok {
+		names := make([]string, 0, len(config.Profiles))
+		for n := range config.Profiles {
+			names = append(names, fmt.Sprintf("%q", n))
+		}
+		sort.Strings(names)
+
+		if len(names) == 0 {
+			return nil, fmt.Errorf("profile %q is not defined in config file %q, which defines no profiles", name, path)
+		}
+		return nil, fmt.Errorf("profile %q is not defined in config file %q; the defined profiles are %s", name, path, strings.Join(names, ", "))
+	}
+
+	return &profile, nil
+}
+
+// checklyProfileFromEnv returns the credentials set through the
+// CHECKLY_API_KEY, CHECKLY_ACCOUNT_ID and CHECKLY_API_URL environment
+// variables.
+func checklyProfileFromEnv() checklyProfile {
+	return checklyProfile{
+		APIKey:    os.Getenv("CHECKLY_API_KEY"),
+		AccountID: os.Getenv("CHECKLY_ACCOUNT_ID"),
+		APIURL:    os.Getenv("CHECKLY_API_URL"),
+	}
+}
+
+// resolveChecklyProfile combines the credentials set in the provider
+// configuration, by the selected profile, which may be nil, and through the
+// environment, in that order of precedence. An explicitly selected profile
+// wins over the environment, so that provider aliases with different profiles
+// don't all pick up the same CHECKLY_API_KEY.
+func resolveChecklyProfile(configured checklyProfile, profile *checklyProfile, env checklyProfile) checklyProfile {
+	if profile == nil {
+		profile = &checklyProfile{}
+	}
+
+	return checklyProfile{
+		APIKey:    cmp.Or(configured.APIKey, profile.APIKey, env.APIKey),
+		AccountID: cmp.Or(configured.AccountID, profile.AccountID, env.AccountID),
+		APIURL:    cmp.Or(configured.APIURL, profile.APIURL, env.APIURL),
+	}
+}
+
+func expandHomeDir(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to expand %q: %w", path, err)
+	}
+
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
+
+// accountValidationPath is a cheap endpoint that describes the account an API
+// key is used with.
+const accountValidationPath = "/v1/accounts/me"
+
+// validateAccount checks that the API key of api is accepted by the API and,
+// if the account ID is set, that it belongs to that account. It goes through
+// the HTTP client of the provider, so that retries and timeouts apply.
+//
+// An API that does not offer the endpoint is not treated as an error, since
+// the credentials may well be valid.
+func validateAccount(ctx context.Context, api *apiClient) error {
+	var account struct {
+		ID string `json:"id"`
+	}
+
+	err := api.get(ctx, accountValidationPath, nil, &account)
+	if err != nil {
+		apiErr := classifyAPIError(err)
+		switch {
+		case apiErr == nil:
+		case apiErr.Kind == apiErrorNotFound:
+			return nil
+		case apiErr.Kind == apiErrorUnauthorized:
+			return errors.New("the Checkly API rejected api_key; check that the key is valid and has not been revoked")
+		case apiErr.Kind == apiErrorForbidden && api.accountID == "":
+			return errors.New("the Checkly API denied access with api_key; set account_id to the account the key belongs to")
+		case apiErr.Kind == apiErrorForbidden:
+			return fmt.Errorf("api_key does not have access to account %q; check that account_id names the account the key belongs to", api.accountID)
+		}
+		return fmt.Errorf("failed to validate the credentials against %s: %w", api.baseURL, err)
+	}
+
+	if api.accountID != "" && account.ID != "" && account.ID != api.accountID {
+		return fmt.Errorf("api_key belongs to account %q, not to account %q set by account_id", account.ID, api.accountID)
+	}
+
+	return nil
+}
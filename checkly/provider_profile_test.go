@@ -0,0 +1,236 @@
+package checkly
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestLoadChecklyProfile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	writeConfig := func(name, content string) string {
+		file := filepath.Join(dir, name)
+		if err := os.WriteFile(file, []byte(content), 0600); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+		return file
+	}
+
+	config := writeConfig("config.json", `{"profiles": {
+		"staging": {"api_key": "cu_staging", "account_id": "acc-staging"},
+		"production": {"api_key": "cu_production", "account_id": "acc-production", "api_url": "https://api.eu.example.com"}
+	}}`)
+	typo := writeConfig("typo.json", `{"profiles": {"staging": {"apikey": "cu_staging"}}}`)
+	empty := writeConfig("empty.json", `{}`)
+
+	tests := []struct {
+		name    string
+		file    string
+		profile string
+		want    checklyProfile
+		wantErr string
+	}{
+		{
+			name:    "profile",
+			file:    config,
+			profile: "production",
+			want:    checklyProfile{APIKey: "cu_production", AccountID: "acc-production", APIURL: "https://api.eu.example.com"},
+		},
+		{
+			name:    "profile without api_url",
+			file:    config,
+			profile: "staging",
+			want:    checklyProfile{APIKey: "cu_staging", AccountID: "acc-staging"},
+		},
+		{
+			name:    "unknown profile",
+			file:    config,
+			profile: "dev",
+			wantErr: `profile "dev" is not defined in config file "` + config + `"; the defined profiles are "production", "staging"`,
+		},
+		{
+			name:    "no profiles",
+			file:    empty,
+			profile: "dev",
+			wantErr: "which defines no profiles",
+		},
+		{
+			name:    "unknown field",
+			file:    typo,
+			profile: "staging",
+			wantErr: `unknown field "apikey"`,
+		},
+		{
+			name:    "missing file",
+			file:    filepath.Join(dir, "missing.json"),
+			profile: "staging",
+			wantErr: `failed to read config file for profile "staging"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := loadChecklyProfile(tt.file, tt.profile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("loadChecklyProfile() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadChecklyProfile failed: %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("loadChecklyProfile() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAccount(t *testing.T) {
+	t.Parallel()
+
+	// The test server knows a single key, which belongs to acc-1.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/missing"+accountValidationPath:
+			w.WriteHeader(http.StatusNotFound)
+		case r.URL.Path != accountValidationPath:
+			t.Errorf("unexpected request path %q", r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		case r.Header.Get("Authorization") != "Bearer cu_valid":
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.Header.Get("X-Checkly-Account") == "acc-other":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			io.WriteString(w, `{"id": "acc-1", "name": "Production"}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name      string
+		path      string
+		apiKey    string
+		accountID string
+		wantErr   string
+	}{
+		{name: "valid", apiKey: "cu_valid", accountID: "acc-1"},
+		{name: "valid without account", apiKey: "cu_valid"},
+		{name: "invalid key", apiKey: "cu_revoked", accountID: "acc-1", wantErr: "the Checkly API rejected api_key"},
+		{name: "no access", apiKey: "cu_valid", accountID: "acc-other", wantErr: `api_key does not have access to account "acc-other"`},
+		{name: "other account", apiKey: "cu_valid", accountID: "acc-2", wantErr: `api_key belongs to account "acc-1", not to account "acc-2"`},
+		{name: "endpoint not offered", path: "/missing", apiKey: "cu_revoked"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := &apiClient{
+				httpClient: server.Client(),
+				baseURL:    server.URL + tt.path,
+				apiKey:     tt.apiKey,
+				accountID:  tt.accountID,
+			}
+
+			err := validateAccount(context.Background(), api)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("validateAccount() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validateAccount failed: %v", err)
+			}
+		})
+	}
+}
+
+func TestResolveChecklyProfile(t *testing.T) {
+	t.Parallel()
+
+	env := checklyProfile{APIKey: "cu_env", AccountID: "acc-env", APIURL: "https://env.example.com"}
+	profile := &checklyProfile{APIKey: "cu_profile", AccountID: "acc-profile"}
+
+	tests := []struct {
+		name       string
+		configured checklyProfile
+		profile    *checklyProfile
+		want       checklyProfile
+	}{
+		{
+			name: "environment only",
+			want: env,
+		},
+		{
+			name:    "profile over environment",
+			profile: profile,
+			want:    checklyProfile{APIKey: "cu_profile", AccountID: "acc-profile", APIURL: "https://env.example.com"},
+		},
+		{
+			name:       "configuration over profile",
+			configured: checklyProfile{APIKey: "cu_configured"},
+			profile:    profile,
+			want:       checklyProfile{APIKey: "cu_configured", AccountID: "acc-profile", APIURL: "https://env.example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveChecklyProfile(tt.configured, tt.profile, env)
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProviderProfileTakesPrecedenceOverEnv(t *testing.T) {
+	t.Setenv("CHECKLY_API_KEY", "cu_env")
+	t.Setenv("CHECKLY_ACCOUNT_ID", "acc-env")
+
+	config := filepath.Join(t.TempDir(), "config.json")
+	err := os.WriteFile(config, []byte(`{"profiles": {
+		"staging": {"api_key": "cu_staging", "account_id": "acc-staging"},
+		"production": {"api_key": "cu_production", "account_id": "acc-production"}
+	}}`), 0600)
+	if err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	for _, name := range []string{"staging", "production"} {
+		t.Run(name, func(t *testing.T) {
+			p := Provider()
+			diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]any{
+				"profile":                 name,
+				"config_file":             config,
+				"skip_account_validation": true,
+			}))
+			if diags.HasError() {
+				t.Fatalf("configure: %v", diags)
+			}
+
+			api := p.Meta().(*providerMeta).api
+			if want := "cu_" + name; api.apiKey != want {
+				t.Errorf("api key: got %q, want %q", api.apiKey, want)
+			}
+			if want := "acc-" + name; api.accountID != want {
+				t.Errorf("account ID: got %q, want %q", api.accountID, want)
+			}
+		})
+	}
+}
@@ -2,7 +2,7 @@ package checkly
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -66,11 +66,12 @@ var webhookTypes = allowedValues[string]{
 
 func resourceAlertChannel() *schema.Resource {
 	return &schema.Resource{
-		Description: "Allows you to define alerting channels for the checks and groups in your account",
-		Create:      resourceAlertChannelCreate,
-		Read:        resourceAlertChannelRead,
-		Update:      resourceAlertChannelUpdate,
-		Delete:      resourceAlertChannelDelete,
+		Description:   "Allows you to define alerting channels for the checks and groups in your account",
+		CreateContext: withAPIErrors(resourceAlertChannelCreate),
+		ReadContext:   withAPIErrors(resourceAlertChannelRead),
+		UpdateContext: withAPIErrors(resourceAlertChannelUpdate),
+		DeleteContext: withAPIErrors(resourceAlertChannelDelete),
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -305,70 +306,57 @@ func resourceAlertChannel() *schema.Resource {
 	}
 }
 
-func resourceAlertChannelCreate(d *schema.ResourceData, client interface{}) error {
+func resourceAlertChannelCreate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	ac, err := alertChannelFromResourceData(d)
 	if err != nil {
-		return makeError("resourceAlertChannelCreate.1", &ErrorLog{"err": err.Error()})
+		return fmt.Errorf("translation error: %w", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	resp, err := client.(checkly.Client).CreateAlertChannel(ctx, ac)
 	if err != nil {
-		cjson, _ := json.Marshal(ac.GetConfig())
-		return makeError("resourceAlertChannelCreate.2", &ErrorLog{
-			"err":    err.Error(),
-			"type":   ac.Type,
-			"config": string(cjson),
-		})
+		return fmt.Errorf("failed to create %s alert channel: %w", ac.Type, err)
 	}
 	d.SetId(fmt.Sprintf("%d", resp.ID))
-	return resourceAlertChannelRead(d, client)
+	return resourceAlertChannelRead(ctx, d, client)
 }
 
-func resourceAlertChannelRead(d *schema.ResourceData, client interface{}) error {
+func resourceAlertChannelRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	ID, err := resourceIDToInt(d.Id())
 	if err != nil {
-		return makeError("resourceAlertChannelRead.1", &ErrorLog{"err": err.Error()})
+		return fmt.Errorf("ID %s is not numeric: %w", d.Id(), err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	ac, err := client.(checkly.Client).GetAlertChannel(ctx, ID)
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			//if resource is deleted remotely, then mark it as
 			//successfully gone by unsetting it's ID
 			d.SetId("")
 			return nil
 		}
-		return makeError("resourceAlertChannelRead.2", &ErrorLog{"err": err.Error()})
+		return fmt.Errorf("failed to retrieve alert channel %q: %w", d.Id(), err)
 	}
 	return resourceDataFromAlertChannel(ac, d)
 }
 
-func resourceAlertChannelUpdate(d *schema.ResourceData, client interface{}) error {
+func resourceAlertChannelUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	ac, err := alertChannelFromResourceData(d)
 	if err != nil {
-		return makeError("resourceAlertChannelUpdate.1", &ErrorLog{"err": err.Error()})
+		return fmt.Errorf("translation error: %w", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	_, err = client.(checkly.Client).UpdateAlertChannel(ctx, ac.ID, ac)
 	if err != nil {
-		return makeError("resourceAlertChannelUpdate.2", &ErrorLog{"err": err.Error()})
+		return fmt.Errorf("failed to update alert channel %q: %w", d.Id(), err)
 	}
 	d.SetId(fmt.Sprintf("%d", ac.ID))
-	return resourceAlertChannelRead(d, client)
+	return resourceAlertChannelRead(ctx, d, client)
 }
 
-func resourceAlertChannelDelete(d *schema.ResourceData, client interface{}) error {
+func resourceAlertChannelDelete(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	ID, err := resourceIDToInt(d.Id())
 	if err != nil {
-		return makeError("resourceAlertChannelDelete.1", &ErrorLog{"err": err.Error()})
+		return fmt.Errorf("ID %s is not numeric: %w", d.Id(), err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	if err := client.(checkly.Client).DeleteAlertChannel(ctx, ID); err != nil {
-		return makeError("resourceAlertChannelDelete.2", &ErrorLog{"err": err.Error()})
+		return fmt.Errorf("failed to delete alert channel %q: %w", d.Id(), err)
 	}
 	return nil
 }
@@ -404,7 +392,7 @@ func alertChannelFromResourceData(d *schema.ResourceData) (checkly.AlertChannel,
 	ac := checkly.AlertChannel{}
 	ID, err := resourceIDToInt(d.Id())
 	if err != nil {
-		return ac, makeError("alertChannelFromResourceData.1", &ErrorLog{"err": err.Error()})
+		return ac, fmt.Errorf("ID %s is not numeric: %w", d.Id(), err)
 	}
 	if err == nil {
 		ac.ID = ID
@@ -435,14 +423,14 @@ func alertChannelFromResourceData(d *schema.ResourceData) (checkly.AlertChannel,
 			ac.Type = strings.ToUpper(field)
 			c, err := alertChannelConfigFromSet(ac.Type, cfgSet)
 			if err != nil {
-				return ac, makeError("alertChannelFromResourceData.2", &ErrorLog{"err": err.Error()})
+				return ac, fmt.Errorf("invalid %s configuration: %w", field, err)
 			}
 			ac.SetConfig(c)
 			setCount++
 		}
 	}
 	if setCount > 1 {
-		return ac, makeError("Alert-Channel config can't contain more than one Channel", nil)
+		return ac, errors.New("alert channel config can't contain more than one channel")
 	}
 	return ac, nil
 }
@@ -506,7 +494,7 @@ func alertChannelConfigFromSet(channelType string, s *schema.Set) (interface{},
 			QueryParameters: keyValuesFromMap(cfg[AcFieldWebhookQueryParams].(tfMap)),
 		}, nil
 	}
-	return nil, makeError("alertChannelConfigFromSet:unkownType", nil)
+	return nil, fmt.Errorf("unknown alert channel type %q", channelType)
 }
 
 func setFromEmail(cfg *checkly.AlertChannelEmail) []tfMap {
@@ -2,11 +2,9 @@ package checkly
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"sort"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -19,10 +17,11 @@ type tfMap = map[string]interface{}
 
 func resourceCheck() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCheckCreate,
-		Read:   resourceCheckRead,
-		Update: resourceCheckUpdate,
-		Delete: resourceCheckDelete,
+		CreateContext: withRuntimeDeprecationWarning(checkRuntimeAttributes, withAPIErrors(resourceCheckCreate)),
+		ReadContext:   withAPIErrors(resourceCheckRead),
+		UpdateContext: withRuntimeDeprecationWarning(checkRuntimeAttributes, withAPIErrors(resourceCheckUpdate)),
+		DeleteContext: withAPIErrors(resourceCheckDelete),
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -161,7 +160,7 @@ func resourceCheck() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     nil,
-				Description: "The id of the runtime to use for this check.",
+				Description: "The id of the runtime to use for this check. When unset, the check runs with the default runtime of its group, or else of the account. The runtime the check runs with is validated when planning; the `checkly_runtimes` data source lists the available runtimes. A deprecated runtime is reported as a warning when the resource is applied, not when it is planned.",
 			},
 			alertChannelSubscriptionAttributeName: makeAlertChannelSubscriptionAttributeSchema(AlertChannelSubscriptionAttributeSchemaOptions{}),
 			"private_locations": {
@@ -336,82 +335,79 @@ func resourceCheck() *schema.Resource {
 				Required:                   false,
 				Computed:                   true,
 			}),
-			"trigger_incident": triggerIncidentAttributeSchema,
+			"trigger_incident":   triggerIncidentAttributeSchema,
+			tagsAllAttributeName: tagsAllAttributeSchema,
+		},
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			ValidateEnvironmentVariableBlocksConfig,
 		},
 		CustomizeDiff: customdiff.Sequence(
 			RetryStrategyCustomizeDiff,
 			FrequencyOffsetCustomizeDiff,
+			TagsAllCustomizeDiff,
+			runtimeCustomizeDiff(checkRuntimeAttributes),
+			LocationsCustomizeDiff,
 		),
 	}
 }
 
-func resourceCheckCreate(d *schema.ResourceData, client interface{}) error {
+func resourceCheckCreate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	check, err := checkFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("translation error: %w", err)
 	}
 
-	validationErr := validateRuntimeSupport(check, client)
-	if validationErr != nil {
-		return validationErr
-	}
+	defaults := providerDefaultsFromMeta(client)
+	check.Tags = defaults.mergeTags(check.Tags)
+	check.Locations = defaults.locationsFor(check.Locations, check.PrivateLocations, check.GroupID)
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	newCheck, err := client.(checkly.Client).CreateCheck(ctx, check)
 
 	if err != nil {
-		checkJSON, _ := json.Marshal(check)
-		return fmt.Errorf("API error 1: %w, Check: %s", err, string(checkJSON))
+		return fmt.Errorf("failed to create check: %w", err)
 	}
 	d.SetId(newCheck.ID)
-	return resourceCheckRead(d, client)
+	return resourceCheckRead(ctx, d, client)
 }
 
-func resourceCheckRead(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceCheckRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	check, err := client.(checkly.Client).GetCheck(ctx, d.Id())
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			//if resource is deleted remotely, then mark it as
 			//successfully gone by unsetting it's ID
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("API error 2: %w", err)
+		return fmt.Errorf("failed to retrieve check %q: %w", d.Id(), err)
 	}
-	return resourceDataFromCheck(check, d)
+	return storeWithProviderDefaults(d, client, func() error {
+		return resourceDataFromCheck(check, d)
+	})
 }
 
-func resourceCheckUpdate(d *schema.ResourceData, client interface{}) error {
+func resourceCheckUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	check, err := checkFromResourceData(d)
 
 	if err != nil {
 		return fmt.Errorf("translation error: %w", err)
 	}
 
-	validationErr := validateRuntimeSupport(check, client)
-	if validationErr != nil {
-		return validationErr
-	}
+	defaults := providerDefaultsFromMeta(client)
+	check.Tags = defaults.mergeTags(check.Tags)
+	check.Locations = defaults.locationsFor(check.Locations, check.PrivateLocations, check.GroupID)
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	_, err = client.(checkly.Client).UpdateCheck(ctx, check.ID, check)
 	if err != nil {
-		checkJSON, _ := json.Marshal(check)
-		return fmt.Errorf("API error 3: Couldn't update check, Error: %w, \nCheck: %s", err, checkJSON)
+		return fmt.Errorf("failed to update check %q: %w", d.Id(), err)
 	}
 	d.SetId(check.ID)
-	return resourceCheckRead(d, client)
+	return resourceCheckRead(ctx, d, client)
 }
 
-func resourceCheckDelete(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceCheckDelete(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	if err := client.(checkly.Client).DeleteCheck(ctx, d.Id()); err != nil {
-		return fmt.Errorf("API error 4: Couldn't delete Check %s, Error: %w", d.Id(), err)
+		return fmt.Errorf("failed to delete check %q: %w", d.Id(), err)
 	}
 	return nil
 }
@@ -705,23 +701,3 @@ func keyValuesFromMap(m map[string]interface{}) []checkly.KeyValue {
 	}
 	return r
 }
-
-func validateRuntimeSupport(check checkly.Check, client interface{}) error {
-	// If the check has a runtime ID set, then validate that the runtime supports multistep.
-	// Note that if the runtime ID is coming from the account defaults or group, then we don't validate it.
-	// Adding validation there as well would be a nice improvement, though.
-	if check.Type == "MULTI_STEP" && check.RuntimeID != nil {
-		ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-		defer cancel()
-		checkRuntime, err := client.(checkly.Client).GetRuntime(ctx, *check.RuntimeID)
-
-		if err != nil {
-			return fmt.Errorf("API error while fetching runtimes: %w", err)
-		}
-
-		if !checkRuntime.MultiStepSupport {
-			return fmt.Errorf("runtime %s does not support MULTI_STEP checks", *check.RuntimeID)
-		}
-	}
-	return nil
-}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -15,10 +14,11 @@ import (
 
 func resourceCheckGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCheckGroupCreate,
-		Read:   resourceCheckGroupRead,
-		Update: resourceCheckGroupUpdate,
-		Delete: resourceCheckGroupDelete,
+		CreateContext: withRuntimeDeprecationWarning(checkGroupRuntimeAttributes, withAPIErrors(resourceCheckGroupCreate)),
+		ReadContext:   withAPIErrors(resourceCheckGroupRead),
+		UpdateContext: withRuntimeDeprecationWarning(checkGroupRuntimeAttributes, withAPIErrors(resourceCheckGroupUpdate)),
+		DeleteContext: withAPIErrors(resourceCheckGroupDelete),
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -105,7 +105,7 @@ func resourceCheckGroup() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     nil,
-				Description: "The id of the runtime to use for this group.",
+				Description: "The id of the runtime to use for this group. It is validated when planning. A deprecated runtime is reported as a warning when the resource is applied, not when it is planned.",
 			},
 			alertChannelSubscriptionAttributeName: makeAlertChannelSubscriptionAttributeSchema(AlertChannelSubscriptionAttributeSchemaOptions{}),
 			alertSettingsAttributeName: makeAlertSettingsAttributeSchema(AlertSettingsAttributeSchemaOptions{
@@ -123,71 +123,68 @@ func resourceCheckGroup() *schema.Resource {
 				Computed:                   true,
 			}),
 		},
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			ValidateEnvironmentVariableBlocksConfig,
+		},
 		CustomizeDiff: customdiff.Sequence(
 			RetryStrategyCustomizeDiff,
+			runtimeCustomizeDiff(checkGroupRuntimeAttributes),
+			LocationsCustomizeDiff,
 		),
 	}
 }
 
-func resourceCheckGroupCreate(d *schema.ResourceData, client interface{}) error {
+func resourceCheckGroupCreate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	group, err := checkGroupFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("translation error: %w", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	gotGroup, err := client.(checkly.Client).CreateGroup(ctx, group)
 	if err != nil {
-		return fmt.Errorf("API error11: %w", err)
+		return fmt.Errorf("failed to create check group: %w", err)
 	}
 	d.SetId(fmt.Sprintf("%d", gotGroup.ID))
-	return resourceCheckGroupRead(d, client)
+	return resourceCheckGroupRead(ctx, d, client)
 }
 
-func resourceCheckGroupRead(d *schema.ResourceData, client interface{}) error {
+func resourceCheckGroupRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	ID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
 		return fmt.Errorf("ID %s is not numeric: %w", d.Id(), err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	group, err := client.(checkly.Client).GetGroup(ctx, ID)
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			//if resource is deleted remotely, then mark it as
 			//successfully gone by unsetting it's ID
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("API error12: %w", err)
+		return fmt.Errorf("failed to retrieve check group %q: %w", d.Id(), err)
 	}
 	return resourceDataFromCheckGroup(group, d)
 }
 
-func resourceCheckGroupUpdate(d *schema.ResourceData, client interface{}) error {
+func resourceCheckGroupUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	group, err := checkGroupFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("translation error: %w", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	_, err = client.(checkly.Client).UpdateGroup(ctx, group.ID, group)
 	if err != nil {
-		return fmt.Errorf("API error13: %w", err)
+		return fmt.Errorf("failed to update check group %q: %w", d.Id(), err)
 	}
 	d.SetId(fmt.Sprintf("%d", group.ID))
-	return resourceCheckGroupRead(d, client)
+	return resourceCheckGroupRead(ctx, d, client)
 }
 
-func resourceCheckGroupDelete(d *schema.ResourceData, client interface{}) error {
+func resourceCheckGroupDelete(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	ID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
 		return fmt.Errorf("ID %s is not numeric: %w", d.Id(), err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	if err := client.(checkly.Client).DeleteGroup(ctx, ID); err != nil {
-		return fmt.Errorf("API error14: %w", err)
+		return fmt.Errorf("failed to delete check group %q: %w", d.Id(), err)
 	}
 	return nil
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"sort"
-	"strings"
 
 	checkly "github.com/checkly/checkly-go-sdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
@@ -23,10 +22,11 @@ const (
 
 func resourceCheckGroupV2() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCheckGroupV2Create,
-		Read:   resourceCheckGroupV2Read,
-		Update: resourceCheckGroupV2Update,
-		Delete: resourceCheckGroupV2Delete,
+		CreateContext: withRuntimeDeprecationWarning(checkGroupV2RuntimeAttributes, withAPIErrors(resourceCheckGroupV2Create)),
+		ReadContext:   withAPIErrors(resourceCheckGroupV2Read),
+		UpdateContext: withRuntimeDeprecationWarning(checkGroupV2RuntimeAttributes, withAPIErrors(resourceCheckGroupV2Update)),
+		DeleteContext: withAPIErrors(resourceCheckGroupV2Delete),
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -152,7 +152,7 @@ func resourceCheckGroupV2() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"runtime_id": {
-							Description: "The runtime ID.",
+							Description: "The runtime ID. It is validated when planning. A deprecated runtime is reported as a warning when the resource is applied, not when it is planned.",
 							Type:        schema.TypeString,
 							Required:    true,
 						},
@@ -275,6 +275,9 @@ func resourceCheckGroupV2() *schema.Resource {
 			},
 			apiCheckDefaultsAttributeName: makeAPICheckDefaultsAttributeSchema(),
 		},
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			ValidateEnvironmentVariableBlocksConfig,
+		},
 		CustomizeDiff: customdiff.Sequence(
 			makeEnabledCustomizeDiffFunc(enforceAlertSettingsAttributeName, func(old, new []any) ([]tfMap, bool) {
 				return nil, false
@@ -295,6 +298,8 @@ func resourceCheckGroupV2() *schema.Resource {
 			makeEnabledCustomizeDiffFunc(enforceSchedulingStrategyAttributeName, func(old, new []any) ([]tfMap, bool) {
 				return nil, false
 			}),
+			runtimeCustomizeDiff(checkGroupV2RuntimeAttributes),
+			makeLocationsCustomizeDiff(enforceLocationsAttributeName+".0.locations"),
 		),
 	}
 }
@@ -371,15 +376,12 @@ func makeEnabledCustomizeDiffFunc(attrName string, f func(old, new []any) ([]tfM
 	}
 }
 
-func resourceCheckGroupV2Create(d *schema.ResourceData, client interface{}) error {
+func resourceCheckGroupV2Create(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	r, err := CheckGroupV2ResourceFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("failed to load check group v2 from resource data: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
-
 	newGroup, err := client.(checkly.Client).CreateGroupV2(ctx, *r.GroupV2)
 	if err != nil {
 		return fmt.Errorf("failed to create check group (v2): %w", err)
@@ -387,21 +389,18 @@ func resourceCheckGroupV2Create(d *schema.ResourceData, client interface{}) erro
 
 	d.SetId(encodeNumericID(newGroup.ID))
 
-	return resourceCheckGroupV2Read(d, client)
+	return resourceCheckGroupV2Read(ctx, d, client)
 }
 
-func resourceCheckGroupV2Read(d *schema.ResourceData, client interface{}) error {
+func resourceCheckGroupV2Read(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	id, err := decodeNumericID(d.Id())
 	if err != nil {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
-
 	group, err := client.(checkly.Client).GetGroupV2(ctx, id)
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -422,15 +421,12 @@ func resourceCheckGroupV2Read(d *schema.ResourceData, client interface{}) error
 	return nil
 }
 
-func resourceCheckGroupV2Update(d *schema.ResourceData, client interface{}) error {
+func resourceCheckGroupV2Update(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	r, err := CheckGroupV2ResourceFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("failed to load check group (v2) %q from resource data: %w", d.Id(), err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
-
 	_, err = client.(checkly.Client).UpdateGroupV2(ctx, r.ID, *r.GroupV2)
 	if err != nil {
 		return fmt.Errorf("failed to update check group (v2) %q: %w", d.Id(), err)
@@ -438,18 +434,15 @@ func resourceCheckGroupV2Update(d *schema.ResourceData, client interface{}) erro
 
 	d.SetId(encodeNumericID(r.ID))
 
-	return resourceCheckGroupV2Read(d, client)
+	return resourceCheckGroupV2Read(ctx, d, client)
 }
 
-func resourceCheckGroupV2Delete(d *schema.ResourceData, client interface{}) error {
+func resourceCheckGroupV2Delete(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	id, err := decodeNumericID(d.Id())
 	if err != nil {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
-
 	if err := client.(checkly.Client).DeleteGroupV2(ctx, id); err != nil {
 		return fmt.Errorf("failed to delete check group (v2) %d: %w", id, err)
 	}
@@ -1002,7 +995,7 @@ func (r *CheckGroupV2Resource) StoreResourceData(
 		return fmt.Errorf("failed to set %q for resource %s: %w", teardownScriptAttributeName, d.Id(), err)
 	}
 
-	err = d.Set(environmentVariableAttributeName, listFromEnvironmentVariables(r.EnvironmentVariables))
+	err = d.Set(environmentVariableAttributeName, listFromEnvironmentVariables(r.EnvironmentVariables, d.Get(environmentVariableAttributeName).([]any)))
 	if err != nil {
 		return err
 	}
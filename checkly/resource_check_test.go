@@ -83,7 +83,7 @@ func TestAccBrowserCheckMissingScript(t *testing.T) {
 	accTestCase(t, []resource.TestStep{
 		{
 			Config:      config,
-			ExpectError: regexp.MustCompile(`API error 1: unexpected response status 400`),
+			ExpectError: regexp.MustCompile(`failed to create check: unexpected response status 400`),
 		},
 	})
 }
@@ -3,8 +3,9 @@ package checkly
 import (
 	"context"
 	"fmt"
-	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	checkly "github.com/checkly/checkly-go-sdk"
@@ -12,9 +13,14 @@ import (
 
 func resourceClientCertificate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceClientCertificateCreate,
-		Read:   resourceClientCertificateRead,
-		Delete: resourceClientCertificateDelete,
+		CreateContext: withClientCertificateExpiryWarning(withAPIErrors(resourceClientCertificateCreate)),
+		ReadContext:   withClientCertificateExpiryWarning(withAPIErrors(resourceClientCertificateRead)),
+		DeleteContext: withAPIErrors(resourceClientCertificateDelete),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultCreateTimeout),
+			Read:   schema.DefaultTimeout(defaultReadTimeout),
+			Delete: schema.DefaultTimeout(defaultDeleteTimeout),
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -27,8 +33,19 @@ func resourceClientCertificate() *schema.Resource {
 			"`acme.com` and will be used automatically by any API checks " +
 			"targeting that domain." +
 			"\n\n" +
-			"Changing the value of any attribute forces a new resource to " +
-			"be created.",
+			"The certificate, private key and trusted CA bundle are parsed " +
+			"when planning, so that a malformed certificate or a key that " +
+			"does not belong to it fails the plan instead of the apply." +
+			"\n\n" +
+			"The Checkly API can't update a client certificate, so changing " +
+			"the value of any attribute forces a new resource to be created. " +
+			"To renew a certificate without a gap, set " +
+			"`lifecycle { create_before_destroy = true }` on the resource, as " +
+			"in the example, so that the new certificate is uploaded before " +
+			"the old one is deleted." +
+			"\n\n" +
+			"A certificate that has expired, or expires within 30 days, is " +
+			"reported as a warning when the resource is created or refreshed.",
 		Schema: map[string]*schema.Schema{
 			"host": {
 				Type:        schema.TypeString,
@@ -61,23 +78,45 @@ func resourceClientCertificate() *schema.Resource {
 				ForceNew:    true,
 				Description: "PEM formatted bundle of CA certificates that the client should trust. The bundle may contain many CA certificates.",
 			},
+			"not_after": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When the certificate expires, in RFC 3339 format.",
+			},
+			"subject": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The subject of the certificate, such as `CN=client.acme.com,O=Acme Inc.`.",
+			},
+			"sans": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The subject alternative names of the certificate: its DNS names, IP addresses, email addresses and URIs.",
+			},
+			"fingerprint_sha256": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA-256 fingerprint of the certificate, in lowercase hex.",
+			},
 		},
+		CustomizeDiff: ClientCertificateCustomizeDiff,
 	}
 }
 
-func resourceClientCertificateCreate(d *schema.ResourceData, client interface{}) error {
+func resourceClientCertificateCreate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	clientCertificate, err := clientCertificateFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("resourceClientCertificateCreate: translation error: %w", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	result, err := client.(checkly.Client).CreateClientCertificate(ctx, clientCertificate)
 	if err != nil {
-		return fmt.Errorf("CreateClientCertificate: API error: %w", err)
+		return fmt.Errorf("failed to create client certificate: %w", err)
 	}
 	d.SetId(result.ID)
-	return resourceClientCertificateRead(d, client)
+	return resourceClientCertificateRead(ctx, d, client)
 }
 
 func clientCertificateFromResourceData(d *schema.ResourceData) (checkly.ClientCertificate, error) {
@@ -101,31 +140,148 @@ func resourceDataFromClientCertificate(c *checkly.ClientCertificate, d *schema.R
 	d.Set("trusted_ca", c.TrustedCA)
 	// The backend does not return a value for Passphrase and even it did, the
 	// value would be encrypted. Thus, the value should never be modified.
+
+	// Certificates uploaded before they were validated may not parse, which
+	// only leaves the computed attributes empty.
+	if certs, err := parseCertificates(c.Certificate); err == nil {
+		return setClientCertificateInfo(d, describeCertificate(certs[0]))
+	}
 	return nil
 }
 
-func resourceClientCertificateRead(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+// setClientCertificateInfo sets the attributes that describe the certificate
+// on d, which is either the resource data or the diff of the resource.
+func setClientCertificateInfo(d interface{ Set(string, any) error }, info clientCertificateInfo) error {
+	values := map[string]any{
+		"not_after":          info.NotAfter.Format(time.RFC3339),
+		"subject":            info.Subject,
+		"sans":               info.SANs,
+		"fingerprint_sha256": info.Fingerprint,
+	}
+	for k, v := range values {
+		if err := d.Set(k, v); err != nil {
+			return fmt.Errorf("error setting %s: %w", k, err)
+		}
+	}
+	return nil
+}
+
+// clientCertificateComputedAttributes are the attributes that are derived
+// from the certificate.
+var clientCertificateComputedAttributes = []string{"not_after", "subject", "sans", "fingerprint_sha256"}
+
+// ClientCertificateCustomizeDiff parses the certificate, its private key and
+// the trusted CA bundle, and plans the attributes derived from the
+// certificate. Values that are not known yet are validated when they are.
+func ClientCertificateCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta any) error {
+	keys := []string{"certificate", "private_key", "passphrase", "trusted_ca"}
+	if diff.Id() != "" && !diff.HasChanges(keys...) {
+		return nil
+	}
+
+	for _, k := range keys {
+		if !diff.NewValueKnown(k) {
+			for _, c := range clientCertificateComputedAttributes {
+				if err := diff.SetNewComputed(c); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	info, err := inspectClientCertificate(
+		diff.Get("certificate").(string),
+		diff.Get("private_key").(string),
+		diff.Get("passphrase").(string),
+		diff.Get("trusted_ca").(string),
+	)
+	if err != nil {
+		return err
+	}
+
+	return setClientCertificateInfo(resourceDiffSetter{diff}, info)
+}
+
+// resourceDiffSetter sets values on a diff through SetNew.
+type resourceDiffSetter struct {
+	diff *schema.ResourceDiff
+}
+
+func (s resourceDiffSetter) Set(key string, value any) error {
+	return s.diff.SetNew(key, value)
+}
+
+func resourceClientCertificateRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	clientCertificate, err := client.(checkly.Client).GetClientCertificate(ctx, d.Id())
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			//if resource is deleted remotely, then mark it as
 			//successfully gone by unsetting it's ID
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("resourceClientCertificateRead: API error: %w", err)
+		return fmt.Errorf("failed to retrieve client certificate %q: %w", d.Id(), err)
 	}
 	return resourceDataFromClientCertificate(clientCertificate, d)
 }
 
-func resourceClientCertificateDelete(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceClientCertificateDelete(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	err := client.(checkly.Client).DeleteClientCertificate(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("resourceClientCertificateDelete: API error: %w", err)
+		return fmt.Errorf("failed to delete client certificate %q: %w", d.Id(), err)
+	}
+	return nil
+}
+
+// clientCertificateExpiryWarningPeriod is how long before it expires a client
+// certificate is reported as expiring soon.
+const clientCertificateExpiryWarningPeriod = 30 * 24 * time.Hour
+
+// withClientCertificateExpiryWarning adds a warning to the result of f, the
+// create or read function of checkly_client_certificate, when the certificate
+// has expired or expires soon.
+func withClientCertificateExpiryWarning(
+	f func(context.Context, *schema.ResourceData, any) diag.Diagnostics,
+) func(context.Context, *schema.ResourceData, any) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+		diags := f(ctx, d, meta)
+		if diags.HasError() || d.Id() == "" {
+			return diags
+		}
+
+		// Certificates that don't parse have no expiry date.
+		notAfter, err := time.Parse(time.RFC3339, d.Get("not_after").(string))
+		if err != nil {
+			return diags
+		}
+
+		if warning := clientCertificateExpiryWarning(d.Get("host").(string), notAfter, time.Now()); warning != nil {
+			diags = append(diags, *warning)
+		}
+
+		return diags
+	}
+}
+
+// clientCertificateExpiryWarning returns a warning when the certificate for
+// host, which expires at notAfter, has expired or expires within
+// clientCertificateExpiryWarningPeriod of now.
+func clientCertificateExpiryWarning(host string, notAfter, now time.Time) *diag.Diagnostic {
+	date := notAfter.Format(time.RFC3339)
+	switch {
+	case !notAfter.After(now):
+		return &diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Client certificate expired",
+			Detail:   fmt.Sprintf("The client certificate for %q expired on %s. Checks targeting the host will fail to authenticate until it is renewed.", host, date),
+		}
+	case notAfter.Sub(now) < clientCertificateExpiryWarningPeriod:
+		return &diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Client certificate expires soon",
+			Detail:   fmt.Sprintf("The client certificate for %q expires on %s. Renew it before then.", host, date),
+		}
 	}
 	return nil
 }
@@ -3,6 +3,7 @@ package checkly
 import (
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
@@ -137,7 +138,42 @@ resource "checkly_client_certificate" "test" {
 						"IQCkSbope5r0KlVdqBeFF8wCfE3plwpelve3jqVIz6MedQ==\n"+
 						"-----END CERTIFICATE-----\n",
 				),
+				resource.TestCheckResourceAttr(
+					"checkly_client_certificate.test",
+					"not_after",
+					"2049-10-23T05:46:52Z",
+				),
 			),
 		},
 	})
 }
+
+func TestClientCertificateExpiryWarning(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		notAfter time.Time
+		want     string
+	}{
+		{name: "valid", notAfter: now.AddDate(1, 0, 0)},
+		{name: "expires soon", notAfter: now.AddDate(0, 0, 10), want: "Client certificate expires soon"},
+		{name: "expired", notAfter: now.AddDate(0, 0, -1), want: "Client certificate expired"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			warning := clientCertificateExpiryWarning("*.acme.com", tt.notAfter, now)
+			switch {
+			case tt.want == "" && warning != nil:
+				t.Errorf("unexpected warning %+v", warning)
+			case tt.want != "" && (warning == nil || warning.Summary != tt.want):
+				t.Errorf("warning = %+v, want summary %q", warning, tt.want)
+			}
+		})
+	}
+}
@@ -3,7 +3,6 @@ package checkly
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -12,10 +11,11 @@ import (
 
 func resourceDashboard() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDashboardCreate,
-		Read:   resourceDashboardRead,
-		Update: resourceDashboardUpdate,
-		Delete: resourceDashboardDelete,
+		CreateContext: withAPIErrors(resourceDashboardCreate),
+		ReadContext:   withAPIErrors(resourceDashboardRead),
+		UpdateContext: withAPIErrors(resourceDashboardUpdate),
+		DeleteContext: withAPIErrors(resourceDashboardDelete),
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -246,17 +246,15 @@ func resourceDataFromDashboard(s *checkly.Dashboard, d *schema.ResourceData) err
 	return nil
 }
 
-func resourceDashboardCreate(d *schema.ResourceData, client interface{}) error {
+func resourceDashboardCreate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	dashboard, err := dashboardFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("resourceDashboardCreate: translation error: %w", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	result, err := client.(checkly.Client).CreateDashboard(ctx, dashboard)
 
 	if err != nil {
-		return fmt.Errorf("CreateDashboard: API error: %w", err)
+		return fmt.Errorf("failed to create dashboard: %w", err)
 	}
 
 	d.SetId(result.DashboardID)
@@ -266,16 +264,14 @@ func resourceDashboardCreate(d *schema.ResourceData, client interface{}) error {
 	return resourceDataFromDashboard(result, d)
 }
 
-func resourceDashboardUpdate(d *schema.ResourceData, client interface{}) error {
+func resourceDashboardUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	dashboard, err := dashboardFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("resourceDashboardUpdate: translation error: %w", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	result, err := client.(checkly.Client).UpdateDashboard(ctx, d.Id(), dashboard)
 	if err != nil {
-		return fmt.Errorf("resourceDashboardUpdate: API error: %w", err)
+		return fmt.Errorf("failed to update dashboard %q: %w", d.Id(), err)
 	}
 	d.SetId(result.DashboardID)
 
@@ -284,26 +280,22 @@ func resourceDashboardUpdate(d *schema.ResourceData, client interface{}) error {
 	return resourceDataFromDashboard(result, d)
 }
 
-func resourceDashboardDelete(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceDashboardDelete(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	err := client.(checkly.Client).DeleteDashboard(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("resourceDashboardDelete: API error: %w", err)
+		return fmt.Errorf("failed to delete dashboard %q: %w", d.Id(), err)
 	}
 	return nil
 }
 
-func resourceDashboardRead(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
+func resourceDashboardRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	dashboard, err := client.(checkly.Client).GetDashboard(ctx, d.Id())
-	defer cancel()
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("resourceDashboardRead: API error: %w", err)
+		return fmt.Errorf("failed to retrieve dashboard %q: %w", d.Id(), err)
 	}
 	return resourceDataFromDashboard(dashboard, d)
 }
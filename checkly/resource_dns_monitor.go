@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"sort"
-	"strings"
 
 	checkly "github.com/checkly/checkly-go-sdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
@@ -13,10 +12,11 @@ import (
 
 func resourceDNSMonitor() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDNSMonitorCreate,
-		Read:   resourceDNSMonitorRead,
-		Update: resourceDNSMonitorUpdate,
-		Delete: resourceDNSMonitorDelete,
+		CreateContext: withAPIErrors(resourceDNSMonitorCreate),
+		ReadContext:   withAPIErrors(resourceDNSMonitorRead),
+		UpdateContext: withAPIErrors(resourceDNSMonitorUpdate),
+		DeleteContext: withAPIErrors(resourceDNSMonitorDelete),
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -196,23 +196,27 @@ func resourceDNSMonitor() *schema.Resource {
 				Required: false,
 				Computed: true,
 			}),
-			"trigger_incident": triggerIncidentAttributeSchema,
+			"trigger_incident":   triggerIncidentAttributeSchema,
+			tagsAllAttributeName: tagsAllAttributeSchema,
 		},
 		CustomizeDiff: customdiff.Sequence(
 			RetryStrategyCustomizeDiff,
 			FrequencyOffsetCustomizeDiff,
+			TagsAllCustomizeDiff,
+			LocationsCustomizeDiff,
 		),
 	}
 }
 
-func resourceDNSMonitorCreate(d *schema.ResourceData, client interface{}) error {
+func resourceDNSMonitorCreate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	check, err := dnsMonitorFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("translation error: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+	defaults := providerDefaultsFromMeta(client)
+	check.Tags = defaults.mergeTags(check.Tags)
+	check.Locations = defaults.locationsFor(check.Locations, check.PrivateLocations, check.GroupID)
 
 	newCheck, err := client.(checkly.Client).CreateDNSMonitor(ctx, check)
 	if err != nil {
@@ -221,16 +225,14 @@ func resourceDNSMonitorCreate(d *schema.ResourceData, client interface{}) error
 
 	d.SetId(newCheck.ID)
 
-	return resourceDNSMonitorRead(d, client)
+	return resourceDNSMonitorRead(ctx, d, client)
 }
 
-func resourceDNSMonitorRead(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceDNSMonitorRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 
 	check, err := client.(checkly.Client).GetDNSMonitor(ctx, d.Id())
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			//if resource is deleted remotely, then mark it as
 			//successfully gone by unsetting it's ID
 			d.SetId("")
@@ -239,17 +241,20 @@ func resourceDNSMonitorRead(d *schema.ResourceData, client interface{}) error {
 		return fmt.Errorf("failed to retrieve DNS monitor '%s': %w", d.Id(), err)
 	}
 
-	return resourceDataFromDNSMonitor(check, d)
+	return storeWithProviderDefaults(d, client, func() error {
+		return resourceDataFromDNSMonitor(check, d)
+	})
 }
 
-func resourceDNSMonitorUpdate(d *schema.ResourceData, client interface{}) error {
+func resourceDNSMonitorUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	check, err := dnsMonitorFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("translation error: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+	defaults := providerDefaultsFromMeta(client)
+	check.Tags = defaults.mergeTags(check.Tags)
+	check.Locations = defaults.locationsFor(check.Locations, check.PrivateLocations, check.GroupID)
 
 	_, err = client.(checkly.Client).UpdateDNSMonitor(ctx, check.ID, check)
 	if err != nil {
@@ -258,12 +263,10 @@ func resourceDNSMonitorUpdate(d *schema.ResourceData, client interface{}) error
 
 	d.SetId(check.ID)
 
-	return resourceDNSMonitorRead(d, client)
+	return resourceDNSMonitorRead(ctx, d, client)
 }
 
-func resourceDNSMonitorDelete(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceDNSMonitorDelete(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 
 	if err := client.(checkly.Client).DeleteDNSMonitor(ctx, d.Id()); err != nil {
 		return fmt.Errorf("failed to delete DNS monitor '%s': %w", d.Id(), err)
@@ -3,8 +3,8 @@ package checkly
 import (
 	"context"
 	"fmt"
-	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	checkly "github.com/checkly/checkly-go-sdk"
@@ -12,21 +12,39 @@ import (
 
 func resourceEnvironmentVariable() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceEnvironmentVariableCreate,
-		Read:   resourceEnvironmentVariableRead,
-		Update: resourceEnvironmentVariableUpdate,
-		Delete: resourceEnvironmentVariableDelete,
+		CreateContext: withAPIErrors(resourceEnvironmentVariableCreate),
+		ReadContext:   withAPIErrors(resourceEnvironmentVariableRead),
+		UpdateContext: withAPIErrors(resourceEnvironmentVariableUpdate),
+		DeleteContext: withAPIErrors(resourceEnvironmentVariableDelete),
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Description: "An environment variable or secret, shared by all checks of the account. " +
+			"To keep the value of a secret out of the Terraform state and plans, set it " +
+			"with the write-only `value_wo`, and bump `value_version` whenever it changes.",
 		Schema: map[string]*schema.Schema{
 			"key": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
 			"value": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The value of the environment variable. The value is stored in the Terraform state; for secrets, consider `value_wo` instead. Exactly one of `value` and `value_wo` must be set.",
+			},
+			writeOnlyValueAttributeName: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				WriteOnly:   true,
+				Sensitive:   true,
+				Description: "The value of the environment variable, which is sent to Checkly but never stored in the Terraform state or shown in plans. Requires Terraform 1.11 or later. Exactly one of `value` and `value_wo` must be set.",
+			},
+			valueVersionAttributeName: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateAtLeast(1),
+				Description:  "The version of `value_wo`, which must be set along with it. Since Terraform can't tell when a write-only value changes, change this to send a new `value_wo` to Checkly.",
 			},
 			"locked": {
 				Type:     schema.TypeBool,
@@ -39,36 +57,49 @@ func resourceEnvironmentVariable() *schema.Resource {
 				Default:  false,
 			},
 		},
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			ValidateEnvironmentVariableValueConfig,
+		},
 	}
 }
 
-func resourceEnvironmentVariableCreate(d *schema.ResourceData, client interface{}) error {
+func resourceEnvironmentVariableCreate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	envVar, err := environmentVariableFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("resourceEnvironmentVariableCreate: translation error: %w", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	_, err = client.(checkly.Client).CreateEnvironmentVariable(ctx, envVar)
 	if err != nil {
-		return fmt.Errorf("CreateEnvironmentVariable: API error: %w", err)
+		return fmt.Errorf("failed to create environment variable: %w", err)
 	}
 	d.SetId(envVar.Key)
-	return resourceEnvironmentVariableRead(d, client)
+	return resourceEnvironmentVariableRead(ctx, d, client)
 }
 
 func environmentVariableFromResourceData(d *schema.ResourceData) (checkly.EnvironmentVariable, error) {
-	return checkly.EnvironmentVariable{
+	envVar := checkly.EnvironmentVariable{
 		Key:    d.Get("key").(string),
 		Value:  d.Get("value").(string),
 		Locked: d.Get("locked").(bool),
 		Secret: d.Get("secret").(bool),
-	}, nil
+	}
+
+	// Write-only values are only available in the configuration.
+	if d.Get(valueVersionAttributeName).(int) != 0 {
+		value, ok := writeOnlyValueFromConfig(d, cty.GetAttrPath(writeOnlyValueAttributeName))
+		if !ok {
+			return checkly.EnvironmentVariable{}, writeOnlyValueError(envVar.Key)
+		}
+		envVar.Value = value
+	}
+
+	return envVar, nil
 }
 
 func resourceDataFromEnvironmentVariable(s *checkly.EnvironmentVariable, d *schema.ResourceData) error {
 	d.Set("key", s.Key)
-	if !s.Secret {
+	// Neither secret nor write-only values are kept in the state.
+	if !s.Secret && d.Get(valueVersionAttributeName).(int) == 0 {
 		d.Set("value", s.Value)
 	}
 	d.Set("locked", s.Locked)
@@ -76,43 +107,37 @@ func resourceDataFromEnvironmentVariable(s *checkly.EnvironmentVariable, d *sche
 	return nil
 }
 
-func resourceEnvironmentVariableRead(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceEnvironmentVariableRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	envVar, err := client.(checkly.Client).GetEnvironmentVariable(ctx, d.Id())
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			//if resource is deleted remotely, then mark it as
 			//successfully gone by unsetting it's ID
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("resourceEnvironmentVariableRead: API error: %w", err)
+		return fmt.Errorf("failed to retrieve environment variable %q: %w", d.Id(), err)
 	}
 	return resourceDataFromEnvironmentVariable(envVar, d)
 }
 
-func resourceEnvironmentVariableUpdate(d *schema.ResourceData, client interface{}) error {
+func resourceEnvironmentVariableUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	envVar, err := environmentVariableFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("resourceEnvironmentVariableUpdate: translation error: %w", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	_, err = client.(checkly.Client).UpdateEnvironmentVariable(ctx, d.Id(), envVar)
 	if err != nil {
-		return fmt.Errorf("resourceEnvironmentVariableUpdate: API error: %w", err)
+		return fmt.Errorf("failed to update environment variable %q: %w", d.Id(), err)
 	}
 
-	return resourceEnvironmentVariableRead(d, client)
+	return resourceEnvironmentVariableRead(ctx, d, client)
 }
 
-func resourceEnvironmentVariableDelete(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceEnvironmentVariableDelete(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	err := client.(checkly.Client).DeleteEnvironmentVariable(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("resourceEnvironmentVariableDelete: API error: %w", err)
+		return fmt.Errorf("failed to delete environment variable %q: %w", d.Id(), err)
 	}
 	return nil
 }
@@ -16,7 +16,7 @@ func TestAccEnvVarCheckRequiredFields(t *testing.T) {
 		},
 		{
 			Config:      config,
-			ExpectError: regexp.MustCompile(`The argument "value" is required`),
+			ExpectError: regexp.MustCompile(`One of value and value_wo must be set`),
 		},
 	})
 }
@@ -56,3 +56,45 @@ func TestAccSecretEnvVarSuccess(t *testing.T) {
 		},
 	})
 }
+
+func TestAccWriteOnlyEnvVarSuccess(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: `resource "checkly_environment_variable" "test" {
+				key           = "WRITE_ONLY_SECRET"
+				value_wo      = "hunter2"
+				value_version = 1
+				secret        = true
+			}`,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckNoResourceAttr("checkly_environment_variable.test", "value_wo"),
+				resource.TestCheckResourceAttr("checkly_environment_variable.test", "value", ""),
+				resource.TestCheckResourceAttr("checkly_environment_variable.test", "value_version", "1"),
+			),
+		},
+		{
+			Config: `resource "checkly_environment_variable" "test" {
+				key           = "WRITE_ONLY_SECRET"
+				value_wo      = "correct horse battery staple"
+				value_version = 2
+				secret        = true
+			}`,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckNoResourceAttr("checkly_environment_variable.test", "value_wo"),
+				resource.TestCheckResourceAttr("checkly_environment_variable.test", "value_version", "2"),
+			),
+		},
+	})
+}
+
+func TestAccWriteOnlyEnvVarMissingVersion(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: `resource "checkly_environment_variable" "test" {
+				key      = "WRITE_ONLY_SECRET"
+				value_wo = "hunter2"
+			}`,
+			ExpectError: regexp.MustCompile(`value_version must be set along with value_wo`),
+		},
+	})
+}
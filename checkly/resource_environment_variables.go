@@ -0,0 +1,462 @@
+package checkly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	checkly "github.com/checkly/checkly-go-sdk"
+)
+
+// environmentVariablesID is the ID of checkly_environment_variables. The
+// variables it manages are tracked in its state, so the ID carries no
+// information.
+const environmentVariablesID = "environment_variables"
+
+func resourceEnvironmentVariables() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withAPIErrors(resourceEnvironmentVariablesCreate),
+		ReadContext:   withAPIErrors(resourceEnvironmentVariablesRead),
+		UpdateContext: withAPIErrors(resourceEnvironmentVariablesUpdate),
+		DeleteContext: withAPIErrors(resourceEnvironmentVariablesDelete),
+		Timeouts:      resourceTimeouts(),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceEnvironmentVariablesImport,
+		},
+		Description: "Manages a set of environment variables at once, from a map or from a " +
+			"`.env` or JSON file that is read locally when planning. The whole set is " +
+			"reconciled in a single resource, so plans stay small even with many variables.\n\n" +
+			"Creating the resource fails if any of the variables already exists, unless " +
+			"`exclusive` is set; import existing variables instead, with their keys, " +
+			"separated by commas, as the ID. Since the API doesn't return the values of " +
+			"secrets, imported secrets are updated on the next apply. Destroying the " +
+			"resource deletes the variables it manages. With `exclusive`, every other " +
+			"variable of the account is deleted, and existing variables are adopted.\n\n" +
+			"The values are stored in the Terraform state. Don't manage the same variable " +
+			"with this resource and `checkly_environment_variable`.",
+		Schema: map[string]*schema.Schema{
+			"variables": {
+				Type:          schema.TypeMap,
+				Optional:      true,
+				Computed:      true,
+				Sensitive:     true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"source_file"},
+				Description:   "The environment variables, by key. When `source_file` is set, this is the content of the file. Exactly one of `variables` and `source_file` must be set.",
+			},
+			"source_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"variables"},
+				ValidateFunc:  validateFileExists(),
+				Description:   "The path of a file with the environment variables. Files with a `.json` extension must contain a JSON object of strings; other files are parsed as dotenv files, with `KEY=value` lines, `#` comments and single- or double-quoted values. Exactly one of `variables` and `source_file` must be set.",
+			},
+			"secret_keys": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The keys of the variables that are secrets, whose values are never visible after they are set.",
+			},
+			"locked_keys": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The keys of the variables that are locked, whose values are not shown by default, but can be accessed.",
+			},
+			"exclusive": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true, every environment variable of the account that is not in the set is deleted. (Default `false`).",
+			},
+			"keys": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The keys of the variables in the set. Unlike `variables`, changes to the keys show up in plans.",
+			},
+		},
+		CustomizeDiff: EnvironmentVariablesCustomizeDiff,
+	}
+}
+
+// EnvironmentVariablesCustomizeDiff resolves the variables of
+// checkly_environment_variables, reading source_file if it is set, and
+// validates their keys.
+func EnvironmentVariablesCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	var desired map[string]string
+
+	switch {
+	case !d.NewValueKnown("source_file"):
+		// The file is only known once it is created.
+		if err := d.SetNewComputed("variables"); err != nil {
+			return err
+		}
+		return d.SetNewComputed("keys")
+	case d.Get("source_file").(string) != "":
+		vars, err := readEnvironmentVariablesFile(d.Get("source_file").(string))
+		if err != nil {
+			return err
+		}
+		desired = vars
+	default:
+		config := d.GetRawConfig()
+		if config.IsNull() {
+			return nil
+		}
+		raw := config.GetAttr("variables")
+		if !raw.IsWhollyKnown() {
+			return d.SetNewComputed("keys")
+		}
+		if raw.IsNull() {
+			return errors.New(`one of "variables" and "source_file" must be set`)
+		}
+		desired = make(map[string]string)
+		for k, v := range raw.AsValueMap() {
+			if v.IsNull() {
+				return fmt.Errorf("the value of environment variable %q must not be null", k)
+			}
+			desired[k] = v.AsString()
+		}
+	}
+
+	if d.NewValueKnown("secret_keys") && d.NewValueKnown("locked_keys") {
+		if err := validateEnvironmentVariableKeys(desired, d.Get("secret_keys").(*schema.Set), d.Get("locked_keys").(*schema.Set)); err != nil {
+			return err
+		}
+	}
+
+	old := stringMapFromAny(d.Get("variables").(map[string]any))
+	if !maps.Equal(old, desired) {
+		if err := d.SetNew("variables", anyMapFromString(desired)); err != nil {
+			return err
+		}
+	}
+
+	keys := slices.Sorted(maps.Keys(desired))
+	if !slices.Equal(keys, stringsFromSet(d.Get("keys").(*schema.Set))) {
+		return d.SetNew("keys", keys)
+	}
+
+	return nil
+}
+
+// validateEnvironmentVariableKeys checks the keys of vars, and that the keys
+// in secretKeys and lockedKeys are in vars.
+func validateEnvironmentVariableKeys(vars map[string]string, secretKeys, lockedKeys *schema.Set) error {
+	var invalid []string
+	for k := range vars {
+		if !environmentVariableKeyRegexp.MatchString(k) {
+			invalid = append(invalid, k)
+		}
+	}
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		return fmt.Errorf("invalid environment variable keys %s: keys must start with a letter or an underscore, followed by letters, digits or underscores", strings.Join(invalid, ", "))
+	}
+
+	for attr, set := range map[string]*schema.Set{"secret_keys": secretKeys, "locked_keys": lockedKeys} {
+		var unknown []string
+		for _, k := range stringsFromSet(set) {
+			if _, ok := vars[k]; !ok {
+				unknown = append(unknown, k)
+			}
+		}
+		if len(unknown) > 0 {
+			sort.Strings(unknown)
+			return fmt.Errorf("%q contains keys that are not in the set of variables: %s", attr, strings.Join(unknown, ", "))
+		}
+	}
+
+	return nil
+}
+
+// environmentVariableChanges are the API calls that reconcile the variables
+// of the account with a set.
+type environmentVariableChanges struct {
+	Create []checkly.EnvironmentVariable
+	Update []checkly.EnvironmentVariable
+	Delete []string
+}
+
+// planEnvironmentVariableChanges compares the variables of the account,
+// remote, with desired. Variables that changed since old, the variables in the
+// state, are updated even if they look the same remotely, since the values of
+// secrets are not returned by the API. Variables in old that are not in
+// desired are deleted, and so is every other variable when exclusive is set.
+func planEnvironmentVariableChanges(remote []checkly.EnvironmentVariable, old, desired map[string]checkly.EnvironmentVariable, exclusive bool) environmentVariableChanges {
+	var changes environmentVariableChanges
+
+	existing := make(map[string]checkly.EnvironmentVariable, len(remote))
+	for _, ev := range remote {
+		existing[ev.Key] = ev
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(desired)) {
+		ev := desired[k]
+		current, ok := existing[k]
+		switch {
+		case !ok:
+			changes.Create = append(changes.Create, ev)
+		case current.Secret:
+			// The API doesn't return the values of secrets, so their values
+			// are compared with the state instead.
+			prev, managed := old[k]
+			if !managed || !sameEnvironmentVariable(prev, ev) || current.Locked != ev.Locked || !ev.Secret {
+				changes.Update = append(changes.Update, ev)
+			}
+		case !sameEnvironmentVariable(current, ev):
+			changes.Update = append(changes.Update, ev)
+		}
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(existing)) {
+		if _, ok := desired[k]; ok {
+			continue
+		}
+		if _, managed := old[k]; managed || exclusive {
+			changes.Delete = append(changes.Delete, k)
+		}
+	}
+
+	return changes
+}
+
+// existingEnvironmentVariableKeys returns the keys of desired that are among
+// the variables of the account, remote, in order.
+func existingEnvironmentVariableKeys(remote []checkly.EnvironmentVariable, desired map[string]checkly.EnvironmentVariable) []string {
+	var keys []string
+	for _, ev := range remote {
+		if _, ok := desired[ev.Key]; ok {
+			keys = append(keys, ev.Key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func sameEnvironmentVariable(a, b checkly.EnvironmentVariable) bool {
+	return a.Key == b.Key && a.Value == b.Value && a.Locked == b.Locked && a.Secret == b.Secret
+}
+
+// environmentVariablesFromSet returns the variables of
+// checkly_environment_variables, as they are in d, or as they were before the
+// change being applied if old is set.
+func environmentVariablesFromSet(d *schema.ResourceData, old bool) map[string]checkly.EnvironmentVariable {
+	get := d.Get
+	if old {
+		get = func(key string) any {
+			v, _ := d.GetChange(key)
+			return v
+		}
+	}
+
+	secretKeys := get("secret_keys").(*schema.Set)
+	lockedKeys := get("locked_keys").(*schema.Set)
+
+	vars := make(map[string]checkly.EnvironmentVariable)
+	for k, v := range get("variables").(map[string]any) {
+		vars[k] = checkly.EnvironmentVariable{
+			Key:    k,
+			Value:  v.(string),
+			Secret: secretKeys.Contains(k),
+			Locked: lockedKeys.Contains(k),
+		}
+	}
+	return vars
+}
+
+// listEnvironmentVariables returns every environment variable of the account.
+func listEnvironmentVariables(ctx context.Context, meta any) ([]checkly.EnvironmentVariable, error) {
+	api, err := apiClientFromMeta(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	vars, err := listAPIPages[checkly.EnvironmentVariable](ctx, api, "/v1/variables", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list environment variables: %w", err)
+	}
+	return vars, nil
+}
+
+// reconcileEnvironmentVariables applies the changes of d to the variables of
+// the account.
+func reconcileEnvironmentVariables(ctx context.Context, d *schema.ResourceData, client interface{}) error {
+	remote, err := listEnvironmentVariables(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	desired := environmentVariablesFromSet(d, false)
+	exclusive := d.Get("exclusive").(bool)
+
+	// Variables that exist before the resource is created may belong to
+	// another resource, which destroying this one would break, so they have
+	// to be imported explicitly.
+	if d.Id() == "" && !exclusive {
+		if keys := existingEnvironmentVariableKeys(remote, desired); len(keys) > 0 {
+			return fmt.Errorf("environment variables %s already exist; import them with %q as the ID, or remove them from the set",
+				strings.Join(keys, ", "), strings.Join(keys, ","))
+		}
+	}
+
+	changes := planEnvironmentVariableChanges(
+		remote,
+		environmentVariablesFromSet(d, true),
+		desired,
+		exclusive,
+	)
+
+	c := client.(checkly.Client)
+	for _, ev := range changes.Create {
+		if _, err := c.CreateEnvironmentVariable(ctx, ev); err != nil {
+			return fmt.Errorf("failed to create environment variable %q: %w", ev.Key, err)
+		}
+	}
+	for _, ev := range changes.Update {
+		if _, err := c.UpdateEnvironmentVariable(ctx, ev.Key, ev); err != nil {
+			return fmt.Errorf("failed to update environment variable %q: %w", ev.Key, err)
+		}
+	}
+	for _, key := range changes.Delete {
+		if err := c.DeleteEnvironmentVariable(ctx, key); err != nil && !isNotFoundError(err) {
+			return fmt.Errorf("failed to delete environment variable %q: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+func resourceEnvironmentVariablesCreate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
+	if err := reconcileEnvironmentVariables(ctx, d, client); err != nil {
+		return err
+	}
+	d.SetId(environmentVariablesID)
+	return resourceEnvironmentVariablesRead(ctx, d, client)
+}
+
+func resourceEnvironmentVariablesRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
+	remote, err := listEnvironmentVariables(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	managed := d.Get("variables").(map[string]any)
+	exclusive := d.Get("exclusive").(bool)
+
+	vars := make(map[string]any)
+	var secretKeys, lockedKeys []string
+	for _, ev := range remote {
+		prev, ok := managed[ev.Key]
+		if !ok && !exclusive {
+			continue
+		}
+
+		// The API doesn't return the values of secrets.
+		if ev.Secret {
+			if !ok {
+				prev = ""
+			}
+			vars[ev.Key] = prev
+		} else {
+			vars[ev.Key] = ev.Value
+		}
+
+		if ev.Secret {
+			secretKeys = append(secretKeys, ev.Key)
+		}
+		if ev.Locked {
+			lockedKeys = append(lockedKeys, ev.Key)
+		}
+	}
+
+	d.Set("variables", vars)
+	d.Set("keys", slices.Sorted(maps.Keys(vars)))
+	d.Set("secret_keys", secretKeys)
+	d.Set("locked_keys", lockedKeys)
+
+	return nil
+}
+
+func resourceEnvironmentVariablesUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
+	if err := reconcileEnvironmentVariables(ctx, d, client); err != nil {
+		return err
+	}
+	return resourceEnvironmentVariablesRead(ctx, d, client)
+}
+
+func resourceEnvironmentVariablesDelete(ctx context.Context, d *schema.ResourceData, client interface{}) error {
+	c := client.(checkly.Client)
+	for _, key := range slices.Sorted(maps.Keys(d.Get("variables").(map[string]any))) {
+		if err := c.DeleteEnvironmentVariable(ctx, key); err != nil && !isNotFoundError(err) {
+			return fmt.Errorf("failed to delete environment variable %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// resourceEnvironmentVariablesImport adopts the variables whose keys are
+// listed, separated by commas, in the ID.
+func resourceEnvironmentVariablesImport(ctx context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+	var keys []string
+	for _, k := range strings.Split(d.Id(), ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("the ID must list the keys of the environment variables to import, separated by commas")
+	}
+
+	remote, err := listEnvironmentVariables(ctx, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]checkly.EnvironmentVariable, len(remote))
+	for _, ev := range remote {
+		existing[ev.Key] = ev
+	}
+
+	vars := make(map[string]any, len(keys))
+	var missing []string
+	for _, k := range keys {
+		ev, ok := existing[k]
+		if !ok {
+			missing = append(missing, k)
+			continue
+		}
+		vars[k] = ev.Value
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("environment variables %s do not exist", strings.Join(missing, ", "))
+	}
+
+	d.SetId(environmentVariablesID)
+	d.Set("variables", vars)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func stringMapFromAny(m map[string]any) map[string]string {
+	res := make(map[string]string, len(m))
+	for k, v := range m {
+		res[k] = v.(string)
+	}
+	return res
+}
+
+func anyMapFromString(m map[string]string) map[string]any {
+	res := make(map[string]any, len(m))
+	for k, v := range m {
+		res[k] = v
+	}
+	return res
+}
@@ -0,0 +1,151 @@
+package checkly
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	checkly "github.com/checkly/checkly-go-sdk"
+)
+
+func TestPlanEnvironmentVariableChanges(t *testing.T) {
+	t.Parallel()
+
+	remote := []checkly.EnvironmentVariable{
+		{Key: "API_URL", Value: "https://old.example.com"},
+		{Key: "REGION", Value: "eu-west-1"},
+		{Key: "TOKEN", Secret: true},
+		{Key: "PASSWORD", Secret: true},
+		{Key: "REMOVED", Value: "gone"},
+		{Key: "UNMANAGED", Value: "other"},
+	}
+	old := map[string]checkly.EnvironmentVariable{
+		"API_URL":  {Key: "API_URL", Value: "https://old.example.com"},
+		"REGION":   {Key: "REGION", Value: "eu-west-1"},
+		"TOKEN":    {Key: "TOKEN", Value: "t0ken", Secret: true},
+		"PASSWORD": {Key: "PASSWORD", Value: "hunter2", Secret: true},
+		"REMOVED":  {Key: "REMOVED", Value: "gone"},
+	}
+	desired := map[string]checkly.EnvironmentVariable{
+		"API_URL":  {Key: "API_URL", Value: "https://new.example.com"},
+		"REGION":   {Key: "REGION", Value: "eu-west-1"},
+		"TOKEN":    {Key: "TOKEN", Value: "t0ken", Secret: true},
+		"PASSWORD": {Key: "PASSWORD", Value: "correct horse", Secret: true},
+		"NEW":      {Key: "NEW", Value: "1", Locked: true},
+	}
+
+	got := planEnvironmentVariableChanges(remote, old, desired, false)
+	want := environmentVariableChanges{
+		Create: []checkly.EnvironmentVariable{{Key: "NEW", Value: "1", Locked: true}},
+		Update: []checkly.EnvironmentVariable{
+			{Key: "API_URL", Value: "https://new.example.com"},
+			{Key: "PASSWORD", Value: "correct horse", Secret: true},
+		},
+		Delete: []string{"REMOVED"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("planEnvironmentVariableChanges() mismatch (-want +got):\n%s", diff)
+	}
+
+	got = planEnvironmentVariableChanges(remote, old, desired, true)
+	want.Delete = []string{"REMOVED", "UNMANAGED"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("planEnvironmentVariableChanges() with exclusive mismatch (-want +got):\n%s", diff)
+	}
+
+	// Imported secrets are updated, since their values are unknown.
+	got = planEnvironmentVariableChanges(remote, map[string]checkly.EnvironmentVariable{
+		"TOKEN": {Key: "TOKEN", Secret: true},
+	}, map[string]checkly.EnvironmentVariable{
+		"TOKEN": {Key: "TOKEN", Value: "t0ken", Secret: true},
+	}, false)
+	want = environmentVariableChanges{
+		Update: []checkly.EnvironmentVariable{{Key: "TOKEN", Value: "t0ken", Secret: true}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("planEnvironmentVariableChanges() importing mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestExistingEnvironmentVariableKeys(t *testing.T) {
+	t.Parallel()
+
+	remote := []checkly.EnvironmentVariable{
+		{Key: "REGION", Value: "eu-west-1"},
+		{Key: "API_URL", Value: "https://api.example.com"},
+		{Key: "UNMANAGED", Value: "other"},
+	}
+	desired := map[string]checkly.EnvironmentVariable{
+		"API_URL": {Key: "API_URL", Value: "https://api.example.com"},
+		"REGION":  {Key: "REGION", Value: "eu-west-1"},
+		"NEW":     {Key: "NEW", Value: "1"},
+	}
+
+	got := existingEnvironmentVariableKeys(remote, desired)
+	if diff := cmp.Diff([]string{"API_URL", "REGION"}, got); diff != "" {
+		t.Errorf("existingEnvironmentVariableKeys() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAccEnvironmentVariablesInvalidKeys(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: `resource "checkly_environment_variables" "test" {
+				variables = {
+					"API-URL" = "https://api.checklyhq.com"
+				}
+			}`,
+			ExpectError: regexp.MustCompile(`invalid environment variable keys API-URL`),
+		},
+		{
+			Config: `resource "checkly_environment_variables" "test" {
+				variables = {
+					API_URL = "https://api.checklyhq.com"
+				}
+				secret_keys = ["API_KEY"]
+			}`,
+			ExpectError: regexp.MustCompile(`"secret_keys" contains keys that are not in the set of variables: API_KEY`),
+		},
+		{
+			Config: `resource "checkly_environment_variables" "test" {
+				variables = {
+					API_URL = null
+				}
+			}`,
+			ExpectError: regexp.MustCompile(`the value of environment variable "API_URL" must not be null`),
+		},
+	})
+}
+
+func TestAccEnvironmentVariablesSuccess(t *testing.T) {
+	accTestCase(t, []resource.TestStep{
+		{
+			Config: `resource "checkly_environment_variables" "test" {
+				variables = {
+					TF_BULK_API_URL = "https://api.checklyhq.com"
+					TF_BULK_API_KEY = "loZd9hOGHDUrGvmW"
+				}
+				secret_keys = ["TF_BULK_API_KEY"]
+			}`,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("checkly_environment_variables.test", "keys.#", "2"),
+				resource.TestCheckTypeSetElemAttr("checkly_environment_variables.test", "keys.*", "TF_BULK_API_URL"),
+				resource.TestCheckTypeSetElemAttr("checkly_environment_variables.test", "secret_keys.*", "TF_BULK_API_KEY"),
+				resource.TestCheckResourceAttr("checkly_environment_variables.test", "variables.TF_BULK_API_URL", "https://api.checklyhq.com"),
+			),
+		},
+		{
+			Config: `resource "checkly_environment_variables" "test" {
+				variables = {
+					TF_BULK_API_URL = "https://api.eu.checklyhq.com"
+				}
+			}`,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("checkly_environment_variables.test", "keys.#", "1"),
+				resource.TestCheckResourceAttr("checkly_environment_variables.test", "variables.TF_BULK_API_URL", "https://api.eu.checklyhq.com"),
+			),
+		},
+	})
+}
@@ -2,10 +2,8 @@ package checkly
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"sort"
-	"strings"
 
 	checkly "github.com/checkly/checkly-go-sdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
@@ -14,10 +12,11 @@ import (
 
 func resourceGRPCMonitor() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGRPCMonitorCreate,
-		Read:   resourceGRPCMonitorRead,
-		Update: resourceGRPCMonitorUpdate,
-		Delete: resourceGRPCMonitorDelete,
+		CreateContext: withAPIErrors(resourceGRPCMonitorCreate),
+		ReadContext:   withAPIErrors(resourceGRPCMonitorRead),
+		UpdateContext: withAPIErrors(resourceGRPCMonitorUpdate),
+		DeleteContext: withAPIErrors(resourceGRPCMonitorDelete),
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -268,72 +267,75 @@ func resourceGRPCMonitor() *schema.Resource {
 				Required: false,
 				Computed: true,
 			}),
-			"trigger_incident": triggerIncidentAttributeSchema,
+			"trigger_incident":   triggerIncidentAttributeSchema,
+			tagsAllAttributeName: tagsAllAttributeSchema,
 		},
 		CustomizeDiff: customdiff.Sequence(
 			RetryStrategyCustomizeDiff,
 			FrequencyOffsetCustomizeDiff,
+			TagsAllCustomizeDiff,
+			LocationsCustomizeDiff,
 		),
 	}
 }
 
-func resourceGRPCMonitorCreate(d *schema.ResourceData, client interface{}) error {
+func resourceGRPCMonitorCreate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	monitor, err := grpcCheckFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("translation error: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+	defaults := providerDefaultsFromMeta(client)
+	monitor.Tags = defaults.mergeTags(monitor.Tags)
+	monitor.Locations = defaults.locationsFor(monitor.Locations, monitor.PrivateLocations, monitor.GroupID)
+
 	newMonitor, err := client.(checkly.Client).CreateGRPCMonitor(ctx, monitor)
 
 	if err != nil {
-		checkJSON, _ := json.Marshal(monitor)
-		return fmt.Errorf("API error 1: %w, Check: %s", err, string(checkJSON))
+		return fmt.Errorf("failed to create gRPC monitor: %w", err)
 	}
 	d.SetId(newMonitor.ID)
-	return resourceGRPCMonitorRead(d, client)
+	return resourceGRPCMonitorRead(ctx, d, client)
 }
 
-func resourceGRPCMonitorRead(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceGRPCMonitorRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	monitor, err := client.(checkly.Client).GetGRPCMonitor(ctx, d.Id())
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			//if resource is deleted remotely, then mark it as
 			//successfully gone by unsetting it's ID
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("API error 2: %w", err)
+		return fmt.Errorf("failed to retrieve gRPC monitor %q: %w", d.Id(), err)
 	}
-	return resourceDataFromGRPCMonitor(monitor, d)
+	return storeWithProviderDefaults(d, client, func() error {
+		return resourceDataFromGRPCMonitor(monitor, d)
+	})
 }
 
-func resourceGRPCMonitorUpdate(d *schema.ResourceData, client interface{}) error {
+func resourceGRPCMonitorUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	monitor, err := grpcCheckFromResourceData(d)
 
 	if err != nil {
 		return fmt.Errorf("translation error: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+	defaults := providerDefaultsFromMeta(client)
+	monitor.Tags = defaults.mergeTags(monitor.Tags)
+	monitor.Locations = defaults.locationsFor(monitor.Locations, monitor.PrivateLocations, monitor.GroupID)
+
 	_, err = client.(checkly.Client).UpdateGRPCMonitor(ctx, monitor.ID, monitor)
 	if err != nil {
-		checkJSON, _ := json.Marshal(monitor)
-		return fmt.Errorf("API error 3: Couldn't update check, Error: %w, \nCheck: %s", err, checkJSON)
+		return fmt.Errorf("failed to update gRPC monitor %q: %w", d.Id(), err)
 	}
 	d.SetId(monitor.ID)
-	return resourceGRPCMonitorRead(d, client)
+	return resourceGRPCMonitorRead(ctx, d, client)
 }
 
-func resourceGRPCMonitorDelete(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceGRPCMonitorDelete(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	if err := client.(checkly.Client).DeleteGRPCMonitor(ctx, d.Id()); err != nil {
-		return fmt.Errorf("API error 4: Couldn't delete Check %s, Error: %w", d.Id(), err)
+		return fmt.Errorf("failed to delete gRPC monitor %q: %w", d.Id(), err)
 	}
 	return nil
 }
@@ -2,11 +2,9 @@ package checkly
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"sort"
-	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -16,10 +14,11 @@ import (
 
 func resourceHeartbeatMonitor() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceHeartbeatMonitorCreate,
-		Read:   resourceHeartbeatMonitorRead,
-		Update: resourceHeartbeatMonitorUpdate,
-		Delete: resourceHeartbeatMonitorDelete,
+		CreateContext: withAPIErrors(resourceHeartbeatMonitorCreate),
+		ReadContext:   withAPIErrors(resourceHeartbeatMonitorRead),
+		UpdateContext: withAPIErrors(resourceHeartbeatMonitorUpdate),
+		DeleteContext: withAPIErrors(resourceHeartbeatMonitorDelete),
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -128,67 +127,67 @@ func resourceHeartbeatMonitor() *schema.Resource {
 			alertChannelSubscriptionAttributeName: makeAlertChannelSubscriptionAttributeSchema(AlertChannelSubscriptionAttributeSchemaOptions{
 				Monitor: true,
 			}),
-			"trigger_incident": triggerIncidentAttributeSchema,
+			"trigger_incident":   triggerIncidentAttributeSchema,
+			tagsAllAttributeName: tagsAllAttributeSchema,
 		},
+		CustomizeDiff: TagsAllCustomizeDiff,
 	}
 }
 
-func resourceHeartbeatMonitorCreate(d *schema.ResourceData, client interface{}) error {
+func resourceHeartbeatMonitorCreate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	monitor, err := heartbeatMonitorFromResourceData(d)
 
 	if err != nil {
 		return fmt.Errorf("translation error: %w", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+
+	monitor.Tags = providerDefaultsFromMeta(client).mergeTags(monitor.Tags)
+
 	newMonitor, err := client.(checkly.Client).CreateHeartbeatMonitor(ctx, monitor)
 
 	if err != nil {
-		checkJSON, _ := json.Marshal(monitor)
-		return fmt.Errorf("API error 1: %w, Check: %s", err, string(checkJSON))
+		return fmt.Errorf("failed to create heartbeat monitor: %w", err)
 	}
 	d.SetId(newMonitor.ID)
-	return resourceHeartbeatMonitorRead(d, client)
+	return resourceHeartbeatMonitorRead(ctx, d, client)
 }
 
-func resourceHeartbeatMonitorRead(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceHeartbeatMonitorRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	monitor, err := client.(checkly.Client).GetHeartbeatMonitor(ctx, d.Id())
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			//if resource is deleted remotely, then mark it as
 			//successfully gone by unsetting it's ID
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("API error 2: %w", err)
+		return fmt.Errorf("failed to retrieve heartbeat monitor %q: %w", d.Id(), err)
 	}
-	return resourceDataFromHeartbeatMonitor(monitor, d)
+	return storeWithProviderDefaults(d, client, func() error {
+		return resourceDataFromHeartbeatMonitor(monitor, d)
+	})
 }
 
-func resourceHeartbeatMonitorUpdate(d *schema.ResourceData, client interface{}) error {
+func resourceHeartbeatMonitorUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	monitor, err := heartbeatMonitorFromResourceData(d)
 
 	if err != nil {
 		return fmt.Errorf("translation error: %w", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+
+	monitor.Tags = providerDefaultsFromMeta(client).mergeTags(monitor.Tags)
+
 	_, err = client.(checkly.Client).UpdateHeartbeatMonitor(ctx, monitor.ID, monitor)
 	if err != nil {
-		checkJSON, _ := json.Marshal(monitor)
-		return fmt.Errorf("API error 3: Couldn't update check, Error: %w, \nCheck: %s", err, checkJSON)
+		return fmt.Errorf("failed to update heartbeat monitor %q: %w", d.Id(), err)
 	}
 	d.SetId(monitor.ID)
-	return resourceHeartbeatMonitorRead(d, client)
+	return resourceHeartbeatMonitorRead(ctx, d, client)
 }
 
-func resourceHeartbeatMonitorDelete(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceHeartbeatMonitorDelete(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	if err := client.(checkly.Client).DeleteHeartbeatMonitor(ctx, d.Id()); err != nil {
-		return fmt.Errorf("API error 4: Couldn't delete Check %s, Error: %w", d.Id(), err)
+		return fmt.Errorf("failed to delete heartbeat monitor %q: %w", d.Id(), err)
 	}
 	return nil
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"sort"
-	"strings"
 
 	checkly "github.com/checkly/checkly-go-sdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
@@ -13,10 +12,11 @@ import (
 
 func resourceICMPMonitor() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceICMPMonitorCreate,
-		Read:   resourceICMPMonitorRead,
-		Update: resourceICMPMonitorUpdate,
-		Delete: resourceICMPMonitorDelete,
+		CreateContext: withAPIErrors(resourceICMPMonitorCreate),
+		ReadContext:   withAPIErrors(resourceICMPMonitorRead),
+		UpdateContext: withAPIErrors(resourceICMPMonitorUpdate),
+		DeleteContext: withAPIErrors(resourceICMPMonitorDelete),
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -172,23 +172,27 @@ func resourceICMPMonitor() *schema.Resource {
 				Required: false,
 				Computed: true,
 			}),
-			"trigger_incident": triggerIncidentAttributeSchema,
+			"trigger_incident":   triggerIncidentAttributeSchema,
+			tagsAllAttributeName: tagsAllAttributeSchema,
 		},
 		CustomizeDiff: customdiff.Sequence(
 			RetryStrategyCustomizeDiff,
 			FrequencyOffsetCustomizeDiff,
+			TagsAllCustomizeDiff,
+			LocationsCustomizeDiff,
 		),
 	}
 }
 
-func resourceICMPMonitorCreate(d *schema.ResourceData, client interface{}) error {
+func resourceICMPMonitorCreate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	monitor, err := icmpMonitorFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("translation error: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+	defaults := providerDefaultsFromMeta(client)
+	monitor.Tags = defaults.mergeTags(monitor.Tags)
+	monitor.Locations = defaults.locationsFor(monitor.Locations, monitor.PrivateLocations, monitor.GroupID)
 
 	newMonitor, err := client.(checkly.Client).CreateICMPMonitor(ctx, monitor)
 	if err != nil {
@@ -197,33 +201,34 @@ func resourceICMPMonitorCreate(d *schema.ResourceData, client interface{}) error
 
 	d.SetId(newMonitor.ID)
 
-	return resourceICMPMonitorRead(d, client)
+	return resourceICMPMonitorRead(ctx, d, client)
 }
 
-func resourceICMPMonitorRead(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceICMPMonitorRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 
 	monitor, err := client.(checkly.Client).GetICMPMonitor(ctx, d.Id())
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
 		return fmt.Errorf("failed to retrieve ICMP monitor '%s': %w", d.Id(), err)
 	}
 
-	return resourceDataFromICMPMonitor(monitor, d)
+	return storeWithProviderDefaults(d, client, func() error {
+		return resourceDataFromICMPMonitor(monitor, d)
+	})
 }
 
-func resourceICMPMonitorUpdate(d *schema.ResourceData, client interface{}) error {
+func resourceICMPMonitorUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	monitor, err := icmpMonitorFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("translation error: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+	defaults := providerDefaultsFromMeta(client)
+	monitor.Tags = defaults.mergeTags(monitor.Tags)
+	monitor.Locations = defaults.locationsFor(monitor.Locations, monitor.PrivateLocations, monitor.GroupID)
 
 	_, err = client.(checkly.Client).UpdateICMPMonitor(ctx, monitor.ID, monitor)
 	if err != nil {
@@ -232,12 +237,10 @@ func resourceICMPMonitorUpdate(d *schema.ResourceData, client interface{}) error
 
 	d.SetId(monitor.ID)
 
-	return resourceICMPMonitorRead(d, client)
+	return resourceICMPMonitorRead(ctx, d, client)
 }
 
-func resourceICMPMonitorDelete(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceICMPMonitorDelete(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 
 	if err := client.(checkly.Client).DeleteICMPMonitor(ctx, d.Id()); err != nil {
 		return fmt.Errorf("failed to delete ICMP monitor '%s': %w", d.Id(), err)
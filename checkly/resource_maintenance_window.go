@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -13,10 +12,11 @@ import (
 
 func resourceMaintenanceWindow() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceMaintenanceWindowCreate,
-		Read:   resourceMaintenanceWindowRead,
-		Update: resourceMaintenanceWindowUpdate,
-		Delete: resourceMaintenanceWindowDelete,
+		CreateContext: withAPIErrors(resourceMaintenanceWindowCreate),
+		ReadContext:   withAPIErrors(resourceMaintenanceWindowRead),
+		UpdateContext: withAPIErrors(resourceMaintenanceWindowUpdate),
+		DeleteContext: withAPIErrors(resourceMaintenanceWindowDelete),
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -118,66 +118,58 @@ func resourceDataFromMaintenanceWindows(s *checkly.MaintenanceWindow, d *schema.
 	return nil
 }
 
-func resourceMaintenanceWindowCreate(d *schema.ResourceData, client interface{}) error {
+func resourceMaintenanceWindowCreate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	mw, err := maintenanceWindowsFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("resourceMaintenanceWindowCreate: translation error: %w", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	result, err := client.(checkly.Client).CreateMaintenanceWindow(ctx, mw)
 
 	if err != nil {
-		return fmt.Errorf("CreateMaintenanceWindows: API error: %w", err)
+		return fmt.Errorf("failed to create maintenance window: %w", err)
 	}
 
 	d.SetId(fmt.Sprintf("%d", result.ID))
-	return resourceMaintenanceWindowRead(d, client)
+	return resourceMaintenanceWindowRead(ctx, d, client)
 }
 
-func resourceMaintenanceWindowUpdate(d *schema.ResourceData, client interface{}) error {
+func resourceMaintenanceWindowUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	mw, err := maintenanceWindowsFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("resourceMaintenanceWindowUpdate: translation error: %w", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	_, err = client.(checkly.Client).UpdateMaintenanceWindow(ctx, mw.ID, mw)
 	if err != nil {
-		return fmt.Errorf("resourceMaintenanceWindowUpdate: API error: %w", err)
+		return fmt.Errorf("failed to update maintenance window %q: %w", d.Id(), err)
 	}
 	d.SetId(fmt.Sprintf("%d", mw.ID))
-	return resourceMaintenanceWindowRead(d, client)
+	return resourceMaintenanceWindowRead(ctx, d, client)
 }
 
-func resourceMaintenanceWindowDelete(d *schema.ResourceData, client interface{}) error {
+func resourceMaintenanceWindowDelete(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	ID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
 		return fmt.Errorf("resourceMaintenanceWindowDelete: ID %s is not numeric: %w", d.Id(), err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	err = client.(checkly.Client).DeleteMaintenanceWindow(ctx, ID)
 	if err != nil {
-		return fmt.Errorf("resourceMaintenanceWindowDelete: API error: %w", err)
+		return fmt.Errorf("failed to delete maintenance window %q: %w", d.Id(), err)
 	}
 	return nil
 }
 
-func resourceMaintenanceWindowRead(d *schema.ResourceData, client interface{}) error {
+func resourceMaintenanceWindowRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	ID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
 		return fmt.Errorf("resourceMaintenanceWindowRead: ID %s is not numeric: %w", d.Id(), err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
 	mw, err := client.(checkly.Client).GetMaintenanceWindow(ctx, ID)
-	defer cancel()
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("resourceMaintenanceWindowRead: API error: %w", err)
+		return fmt.Errorf("failed to retrieve maintenance window %q: %w", d.Id(), err)
 	}
 	return resourceDataFromMaintenanceWindows(mw, d)
 }
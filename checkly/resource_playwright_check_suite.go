@@ -22,12 +22,17 @@ var defaultTestCommand = map[string]string{
 	"bun":  "bunx playwright test",
 }
 
+// yarnBerryInstallCommand is the install command for Yarn Berry (v2+)
+// projects, which replaced classic Yarn's --frozen-lockfile with --immutable.
+const yarnBerryInstallCommand = "yarn install --immutable"
+
 func resourcePlaywrightCheckSuite() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePlaywrightCheckSuiteCreate,
-		Read:   resourcePlaywrightCheckSuiteRead,
-		Update: resourcePlaywrightCheckSuiteUpdate,
-		Delete: resourcePlaywrightCheckSuiteDelete,
+		CreateContext: withAPIErrors(resourcePlaywrightCheckSuiteCreate),
+		ReadContext:   withAPIErrors(resourcePlaywrightCheckSuiteRead),
+		UpdateContext: withAPIErrors(resourcePlaywrightCheckSuiteUpdate),
+		DeleteContext: withAPIErrors(resourcePlaywrightCheckSuiteDelete),
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -164,6 +169,7 @@ func resourcePlaywrightCheckSuite() *schema.Resource {
 											"environment prior to starting the test run.",
 										Type:     schema.TypeList,
 										Optional: true,
+										Computed: true,
 										MaxItems: 1,
 										Elem: &schema.Resource{
 											Schema: map[string]*schema.Schema{
@@ -171,9 +177,11 @@ func resourcePlaywrightCheckSuite() *schema.Resource {
 													Description: "The command used to install dependencies prior to " +
 														"running Playwright. The default value is the appropriate " +
 														"install command for your package manager (e.g. " +
-														"`npm install` for `npm`).",
+														"`npm install` for `npm`, or `yarn install --immutable` " +
+														"for Yarn Berry).",
 													Type:     schema.TypeString,
 													Optional: true,
+													Computed: true,
 												},
 											},
 										},
@@ -271,9 +279,15 @@ func resourcePlaywrightCheckSuite() *schema.Resource {
 				Type:        schema.TypeInt,
 				Optional:    true,
 			},
-			"trigger_incident": triggerIncidentAttributeSchema,
+			"trigger_incident":   triggerIncidentAttributeSchema,
+			tagsAllAttributeName: tagsAllAttributeSchema,
+		},
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			ValidateEnvironmentVariableBlocksConfig,
 		},
 		CustomizeDiff: customdiff.Sequence(
+			TagsAllCustomizeDiff,
+			LocationsCustomizeDiff,
 			func(ctx context.Context, diff *schema.ResourceDiff, meta any) error {
 				runtimeListAttr := diff.GetRawConfig().GetAttr("runtime")
 
@@ -284,6 +298,7 @@ func resourcePlaywrightCheckSuite() *schema.Resource {
 				var isRuntimePlaywrightDeviceBlockPresent bool
 				var isRuntimeStepsBlockPresent bool
 				var isRuntimeStepsInstallBlockPresent bool
+				var isRuntimeStepsInstallCommandPresent bool
 				var isRuntimeStepsTestBlockPresent bool
 				var isRuntimeStepsTestCommandPresent bool
 				var isRuntimeEngineBlockPresent bool
@@ -330,6 +345,12 @@ func resourcePlaywrightCheckSuite() *schema.Resource {
 						installIt := installListAttr.ElementIterator()
 						if installIt.Next() {
 							isRuntimeStepsInstallBlockPresent = true
+
+							_, installAttr := installIt.Element()
+
+							commandAttr := installAttr.GetAttr("command")
+
+							isRuntimeStepsInstallCommandPresent = !commandAttr.IsNull()
 						}
 
 						testListAttr := stepsAttr.GetAttr("test")
@@ -416,6 +437,13 @@ func resourcePlaywrightCheckSuite() *schema.Resource {
 					}
 				}
 
+				if !isRuntimeStepsInstallCommandPresent {
+					if runtimeAttr.Steps != nil && runtimeAttr.Steps.Install != nil && runtimeAttr.Steps.Install.Command != "" {
+						runtimeAttr.Steps.Install.Command = ""
+						overrideRuntime = true
+					}
+				}
+
 				if !isRuntimeStepsTestBlockPresent {
 					if runtimeAttr.Steps != nil && runtimeAttr.Steps.Test != nil {
 						runtimeAttr.Steps.Test = nil
@@ -438,7 +466,7 @@ func resourcePlaywrightCheckSuite() *schema.Resource {
 				}
 
 				if isRuntimeEngineBlockPresent && runtimeAttr.Engine != nil {
-					config, ok := engineConfigs[runtimeAttr.Engine.Name]
+					config, ok := engineRuleSetFromMeta(meta).Configs[runtimeAttr.Engine.Name]
 					if ok {
 						res := resolveEngineVersion(runtimeAttr.Engine.Version, config)
 						if res.Denied || len(res.Notices) > 0 {
@@ -485,6 +513,22 @@ func resourcePlaywrightCheckSuite() *schema.Resource {
 						runtimeAttr.Steps = stepsAttr
 					}
 
+					// Yarn Berry rejects classic Yarn's install flags, so
+					// pick its install command explicitly rather than
+					// leaving it to the backend default.
+					if bundleAttr.Metadata.PackageManager == "yarn" && bundleAttr.Metadata.YarnBerry {
+						installAttr := stepsAttr.Install
+						if installAttr == nil {
+							installAttr = &PlaywrightCheckSuiteRuntimeStepsInstallAttribute{}
+							stepsAttr.Install = installAttr
+						}
+
+						if installAttr.Command == "" {
+							installAttr.Command = yarnBerryInstallCommand
+							overrideRuntime = true
+						}
+					}
+
 					testAttr := stepsAttr.Test
 					if testAttr == nil {
 						testAttr = &PlaywrightCheckSuiteRuntimeStepsTestAttribute{}
@@ -571,14 +615,15 @@ func resourcePlaywrightCheckSuite() *schema.Resource {
 	}
 }
 
-func resourcePlaywrightCheckSuiteCreate(d *schema.ResourceData, client any) error {
+func resourcePlaywrightCheckSuiteCreate(ctx context.Context, d *schema.ResourceData, client any) error {
 	r, err := PlaywrightCheckSuiteResourceFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("failed to load Playwright check suite from resource data: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+	defaults := providerDefaultsFromMeta(client)
+	r.Tags = defaults.mergeTags(r.Tags)
+	r.Locations = defaults.locationsFor(r.Locations, r.PrivateLocations, r.GroupID)
 
 	newCheck, err := client.(checkly.Client).CreatePlaywrightCheck(ctx, *r.PlaywrightCheck)
 	if err != nil {
@@ -587,16 +632,13 @@ func resourcePlaywrightCheckSuiteCreate(d *schema.ResourceData, client any) erro
 
 	d.SetId(newCheck.ID)
 
-	return resourcePlaywrightCheckSuiteRead(d, client)
+	return resourcePlaywrightCheckSuiteRead(ctx, d, client)
 }
 
-func resourcePlaywrightCheckSuiteRead(d *schema.ResourceData, client any) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
-
+func resourcePlaywrightCheckSuiteRead(ctx context.Context, d *schema.ResourceData, client any) error {
 	check, err := client.(checkly.Client).GetPlaywrightCheck(ctx, d.Id())
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -609,7 +651,9 @@ func resourcePlaywrightCheckSuiteRead(d *schema.ResourceData, client any) error
 		return fmt.Errorf("failed to convert API response to Playwright check suite resource: %w", err)
 	}
 
-	err = resource.StoreResourceData(d)
+	err = storeWithProviderDefaults(d, client, func() error {
+		return resource.StoreResourceData(d)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to store Playwright check suite %q state: %w", d.Id(), err)
 	}
@@ -617,14 +661,15 @@ func resourcePlaywrightCheckSuiteRead(d *schema.ResourceData, client any) error
 	return nil
 }
 
-func resourcePlaywrightCheckSuiteUpdate(d *schema.ResourceData, client any) error {
+func resourcePlaywrightCheckSuiteUpdate(ctx context.Context, d *schema.ResourceData, client any) error {
 	r, err := PlaywrightCheckSuiteResourceFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("failed to load Playwright check suite from resource data: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+	defaults := providerDefaultsFromMeta(client)
+	r.Tags = defaults.mergeTags(r.Tags)
+	r.Locations = defaults.locationsFor(r.Locations, r.PrivateLocations, r.GroupID)
 
 	_, err = client.(checkly.Client).UpdatePlaywrightCheck(ctx, r.ID, *r.PlaywrightCheck)
 	if err != nil {
@@ -633,13 +678,10 @@ func resourcePlaywrightCheckSuiteUpdate(d *schema.ResourceData, client any) erro
 
 	d.SetId(r.ID)
 
-	return resourcePlaywrightCheckSuiteRead(d, client)
+	return resourcePlaywrightCheckSuiteRead(ctx, d, client)
 }
 
-func resourcePlaywrightCheckSuiteDelete(d *schema.ResourceData, client any) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
-
+func resourcePlaywrightCheckSuiteDelete(ctx context.Context, d *schema.ResourceData, client any) error {
 	if err := client.(checkly.Client).DeletePlaywrightCheck(ctx, d.Id()); err != nil {
 		return fmt.Errorf("failed to delete Playwright check suite %q: %w", d.Id(), err)
 	}
@@ -842,7 +884,7 @@ func (r *PlaywrightCheckSuiteResource) StoreResourceData(
 	d.Set("locations", r.Locations)
 	d.Set("private_locations", r.PrivateLocations)
 
-	d.Set(environmentVariableAttributeName, listFromEnvironmentVariables(r.EnvironmentVariables))
+	d.Set(environmentVariableAttributeName, listFromEnvironmentVariables(r.EnvironmentVariables, d.Get(environmentVariableAttributeName).([]any)))
 
 	sort.Strings(r.Tags)
 	d.Set("tags", r.Tags)
@@ -12,36 +12,57 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"os"
 	"path"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	checkly "github.com/checkly/checkly-go-sdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
 )
 
 const (
-	prebuiltArchiveAttributeName = "prebuilt_archive"
-	metadataAttributeName        = "metadata"
+	prebuiltArchiveAttributeName  = "prebuilt_archive"
+	sourceDirectoryAttributeName  = "source_directory"
+	projectPathAttributeName      = "project_path"
+	playwrightConfigAttributeName = "playwright_config"
+	metadataAttributeName         = "metadata"
+	metadataChangesAttributeName  = "metadata_changes"
+	archivePolicyAttributeName    = "archive_policy"
 )
 
+// defaultCodeBundleCreateTimeout bounds the upload of a code bundle, which is
+// exempt from the request timeout since it grows with the archive.
+const defaultCodeBundleCreateTimeout = 30 * time.Minute
+
 func resourcePlaywrightCodeBundle() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourcePlaywrightCodeBundleCreate,
 		ReadContext:   resourcePlaywrightCodeBundleRead,
+		UpdateContext: resourcePlaywrightCodeBundleUpdate,
 		DeleteContext: resourcePlaywrightCodeBundleDelete,
-		Description:   "A managed code bundle which can be used in Playwright Check Suite resources.",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultCodeBundleCreateTimeout),
+			Read:   schema.DefaultTimeout(defaultReadTimeout),
+		},
+		Description: "A managed code bundle which can be used in Playwright Check Suite resources.",
 		Schema: map[string]*schema.Schema{
 			prebuiltArchiveAttributeName: {
-				Description: "A prebuilt archive containing the code bundle.",
-				Type:        schema.TypeList,
-				Required:    true,
-				ForceNew:    true,
-				MaxItems:    1,
+				Description: "A prebuilt archive containing the code bundle. " +
+					"Exactly one of `prebuilt_archive` or `source_directory` " +
+					"must be set.",
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{prebuiltArchiveAttributeName, sourceDirectoryAttributeName},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"file": {
@@ -54,6 +75,144 @@ func resourcePlaywrightCodeBundle() *schema.Resource {
 					},
 				},
 			},
+			sourceDirectoryAttributeName: {
+				Description: "A directory from which the provider builds the " +
+					"code bundle archive. The archive is packed " +
+					"deterministically (sorted entries, zeroed timestamps " +
+					"and ownership), so the bundle only changes when the " +
+					"packed content changes. `.git`, `node_modules`, " +
+					"`test-results`, `playwright-report` and `blob-report` " +
+					"are always excluded. Exactly one of `prebuilt_archive` " +
+					"or `source_directory` must be set.",
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{prebuiltArchiveAttributeName, sourceDirectoryAttributeName},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Description:  "Path to the directory containing the Playwright project.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validateDirectoryExists(),
+						},
+						"include": {
+							Description: "Patterns in `.gitignore` syntax selecting " +
+								"the files to pack. A pattern matching a " +
+								"directory selects everything below it. When " +
+								"empty, every file is packed.",
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"exclude": {
+							Description: "Patterns in `.gitignore` syntax removing " +
+								"files from the archive. They are evaluated " +
+								"after the built-in exclusions, so a negated " +
+								"pattern such as `!test-results/` brings a " +
+								"built-in exclusion back.",
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"respect_gitignore": {
+							Description: "Whether to skip files ignored by any " +
+								"`.gitignore` in the directory tree. " +
+								"(Default `true`).",
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+							Default:  true,
+						},
+					},
+				},
+			},
+			projectPathAttributeName: {
+				Description: "The directory of the Playwright project inside " +
+					"the archive, relative to its root. It must contain a " +
+					"`package.json`. Use this when the archive holds several " +
+					"Playwright projects, such as a workspace monorepo. " +
+					"Conflicts with `playwright_config`.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{playwrightConfigAttributeName},
+				ValidateFunc:  validateArchiveRelativePath(),
+			},
+			playwrightConfigAttributeName: {
+				Description: "The Playwright config file inside the archive, " +
+					"relative to its root. The project is the closest " +
+					"directory above it that contains a `package.json`. " +
+					"Conflicts with `project_path`.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{projectPathAttributeName},
+				ValidateFunc:  validateArchiveRelativePath(),
+			},
+			archivePolicyAttributeName: {
+				Description: "Rules the archive must follow. They are " +
+					"checked during plan, and every violation is reported " +
+					"at once. Changing the policy does not replace the " +
+					"bundle.",
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_compressed_bytes": {
+							Description:  "The maximum size of the archive file, in bytes. (Default `0`, no limit).",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validateAtLeast(0),
+						},
+						"max_uncompressed_bytes": {
+							Description:  "The maximum total size of the files in the archive, in bytes. (Default `0`, no limit).",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validateAtLeast(0),
+						},
+						"max_files": {
+							Description:  "The maximum number of files in the archive. (Default `0`, no limit).",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validateAtLeast(0),
+						},
+						"denied_paths": {
+							Description: "Patterns in `.gitignore` syntax, " +
+								"relative to the archive root, that no file " +
+								"in the archive may match, for example " +
+								"`node_modules/` or `*.har`.",
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"detect_secrets": {
+							Description: "Whether to reject `.env` files " +
+								"and files containing common credential " +
+								"formats, such as private keys and AWS, " +
+								"GitHub, npm, Slack, Stripe or Google API " +
+								"keys. (Default `true`).",
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+					},
+				},
+			},
 			metadataAttributeName: {
 				Description: "An opaque blob of generated metadata. The " +
 					"value is not intended to be user-consumable and should " +
@@ -62,6 +221,18 @@ func resourcePlaywrightCodeBundle() *schema.Resource {
 				Computed: true,
 				ForceNew: true,
 			},
+			metadataChangesAttributeName: {
+				Description: "Human-readable descriptions of how the " +
+					"metadata differs from that of the bundle this one " +
+					"replaced, for example `playwright 1.47.0 → 1.49.1` or " +
+					"`cache hash changed because pnpm-lock.yaml changed`. " +
+					"Empty for a bundle that did not replace another one.",
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, meta any) error {
@@ -72,88 +243,105 @@ func resourcePlaywrightCodeBundle() *schema.Resource {
 
 				switch {
 				case bundle.PrebuiltArchive != nil:
-					// Run before the checksum comparison below, so that an
-					// archive which is already in state gets validated too,
-					// not just one that changed.
-					if err := bundle.PrebuiltArchive.InspectArchivePaths(); err != nil {
-						return err
-					}
-
-					checksum, err := bundle.PrebuiltArchive.ChecksumSha256()
+					return updatePlaywrightCodeBundleMetadata(diff, bundle.Data, bundle.PrebuiltArchive, bundle.WorkingDirOptions, bundle.ArchivePolicy, engineRuleSetFromMeta(meta))
+				case bundle.SourceDirectory != nil:
+					archive, cleanup, err := bundle.SourceDirectory.BuildArchive()
 					if err != nil {
-						return fmt.Errorf("failed to calculate source archive checksum: %v", err)
+						return fmt.Errorf("failed to build archive from source directory: %w", err)
 					}
+					defer cleanup()
 
-					switch {
-					case bundle.Data.Version < PlaywrightCodeBundleMetadataCurrentVersion:
-						// Older provider has been upgraded to a newer version.
-						// Data should be updated.
-					case bundle.Data.Version > PlaywrightCodeBundleMetadataCurrentVersion:
-						// A newer provider has been downgraded to our version.
-						// Data should be updated.
-					case checksum != bundle.Data.ChecksumSha256:
-						// Data should be updated.
-					default:
-						// Data needs no update.
-						return nil
-					}
+					return updatePlaywrightCodeBundleMetadata(diff, bundle.Data, archive, bundle.WorkingDirOptions, bundle.ArchivePolicy, engineRuleSetFromMeta(meta))
+				default:
+					return fmt.Errorf("bundle has no source")
+				}
+			},
+		),
+	}
+}
 
-					lockfileInfo, err := bundle.PrebuiltArchive.InspectLockfile("@playwright/test", InspectLockfileOptions{
-						PackageJSONExcludedFields: []string{
-							// Exclude "version" because CI workflows often
-							// stamp it with a commit hash or build number.
-							// Including it would invalidate the dependency
-							// cache on every build even when no dependencies
-							// actually changed.
-							"version",
-						},
-					})
-					if err != nil {
-						return fmt.Errorf("failed to inspect lockfile in archive: %w", err)
-					}
+// updatePlaywrightCodeBundleMetadata inspects the archive and, if the stored
+// metadata is stale, plans the new value of the metadata attribute.
+func updatePlaywrightCodeBundleMetadata(
+	diff *schema.ResourceDiff,
+	data *PlaywrightCodeBundleMetadata,
+	archive *PlaywrightCodeBundlePrebuiltArchiveAttribute,
+	workingDirOpts WorkingDirOptions,
+	policy *PlaywrightCodeBundleArchivePolicy,
+	engineRules *engineRuleSet,
+) error {
+	// Run before the checksum comparison below, so that an archive which is
+	// already in state gets validated too, not just one that changed.
+	if err := archive.InspectArchivePaths(); err != nil {
+		return err
+	}
 
-					if lockfileInfo == nil {
-						return fmt.Errorf(
-							"no lockfile found at the root of the archive; " +
-								"the archive must contain a package-lock.json, pnpm-lock.yaml, yarn.lock, or bun.lock at the root level",
-						)
-					}
+	if err := archive.InspectArchivePolicy(policy); err != nil {
+		return err
+	}
 
-					if lockfileInfo.PackageVersion == "" {
-						return fmt.Errorf(
-							"the lockfile does not contain @playwright/test; " +
-								"add @playwright/test to the project's dependencies and regenerate the lockfile",
-						)
-					}
+	checksum, err := archive.ChecksumSha256()
+	if err != nil {
+		return fmt.Errorf("failed to calculate source archive checksum: %v", err)
+	}
 
-					workingDir, err := bundle.PrebuiltArchive.DetectWorkingDir()
-					if err != nil {
-						return fmt.Errorf("failed to detect working directory in archive: %v", err)
-					}
+	switch {
+	case data.Version < PlaywrightCodeBundleMetadataCurrentVersion:
+		// Older provider has been upgraded to a newer version.
+		// Data should be updated.
+	case data.Version > PlaywrightCodeBundleMetadataCurrentVersion:
+		// A newer provider has been downgraded to our version.
+		// Data should be updated.
+	case checksum != data.ChecksumSha256:
+		// Data should be updated.
+	case diff.HasChange(projectPathAttributeName), diff.HasChange(playwrightConfigAttributeName):
+		// A different project was selected. Data should be updated.
+	case data.EngineRawVersion != "" && data.EngineRuleSet != engineRules.ID:
+		// The engine rules changed and may resolve to a different version.
+		// Data should be updated.
+	default:
+		// Data needs no update.
+		return nil
+	}
 
-					bundle.Data.Version = PlaywrightCodeBundleMetadataCurrentVersion
-					bundle.Data.ChecksumSha256 = checksum
-					bundle.Data.PlaywrightVersion = lockfileInfo.PackageVersion
-					bundle.Data.PackageManager = lockfileInfo.PackageManager
-					bundle.Data.CacheHash = lockfileInfo.ChecksumSha256
-					bundle.Data.WorkingDir = workingDir
-					bundle.Data.Engine = lockfileInfo.Engine
-					bundle.Data.EngineVersion = lockfileInfo.EngineVersion
-					bundle.Data.EngineRawVersion = lockfileInfo.EngineRawVersion
-					bundle.Data.EngineSource = lockfileInfo.EngineSource
-
-					err = diff.SetNew(metadataAttributeName, bundle.Data.EncodeToString())
-					if err != nil {
-						return fmt.Errorf("failed to set %q: %v", metadataAttributeName, err)
-					}
+	inspection, err := archive.Inspect(workingDirOpts, engineRules)
+	if err != nil {
+		return err
+	}
 
-					return nil
-				default:
-					return fmt.Errorf("bundle has no source")
-				}
-			},
-		),
+	previous := *data
+
+	data.Version = PlaywrightCodeBundleMetadataCurrentVersion
+	data.ChecksumSha256 = checksum
+	data.PlaywrightVersion = inspection.Lockfile.PackageVersion
+	data.PackageManager = inspection.Lockfile.PackageManager
+	data.CacheHash = inspection.Lockfile.ChecksumSha256
+	data.CacheInputs = inspection.Lockfile.CacheInputs
+	data.WorkingDir = inspection.WorkingDir
+	data.Engine = inspection.Lockfile.Engine
+	data.EngineVersion = inspection.Lockfile.EngineVersion
+	data.EngineRawVersion = inspection.Lockfile.EngineRawVersion
+	data.EngineSource = inspection.Lockfile.EngineSource
+	data.EngineRuleSet = inspection.Lockfile.EngineRuleSet
+	data.YarnBerry = inspection.Lockfile.YarnBerry
+	data.YarnPnP = inspection.Lockfile.YarnPnP
+
+	err = diff.SetNew(metadataAttributeName, data.EncodeToString())
+	if err != nil {
+		return fmt.Errorf("failed to set %q: %v", metadataAttributeName, err)
+	}
+
+	changes := []string{}
+	if previous.Version != 0 {
+		changes = describePlaywrightCodeBundleMetadataChanges(&previous, data)
+	}
+
+	err = diff.SetNew(metadataChangesAttributeName, changes)
+	if err != nil {
+		return fmt.Errorf("failed to set %q: %v", metadataChangesAttributeName, err)
 	}
+
+	return nil
 }
 
 func resourcePlaywrightCodeBundleCreate(
@@ -161,9 +349,6 @@ func resourcePlaywrightCodeBundleCreate(
 	d *schema.ResourceData,
 	client any,
 ) (diags diag.Diagnostics) {
-	ctx, cancel := context.WithTimeout(ctx, apiCallTimeout())
-	defer cancel()
-
 	bundle, err := PlaywrightCodeBundleResourceFromResourceData(d)
 	if err != nil {
 		return diag.Errorf("failed to thaw code bundle from resource data: %v", err)
@@ -173,7 +358,7 @@ func resourcePlaywrightCodeBundleCreate(
 	case bundle.PrebuiltArchive != nil:
 		result, err := bundle.PrebuiltArchive.Upload(ctx, client.(checkly.Client))
 		if err != nil {
-			return diag.Errorf("failed to upload source archive: %v", err)
+			return apiErrorDiagnostics(fmt.Errorf("failed to upload source archive: %w", err), d.GetRawConfig())
 		}
 
 		d.SetId(base64.StdEncoding.EncodeToString([]byte(result.Key)))
@@ -188,6 +373,45 @@ func resourcePlaywrightCodeBundleCreate(
 			return diag.Errorf("failed to set %q state: %v", metadataAttributeName, err)
 		}
 
+		return nil
+	case bundle.SourceDirectory != nil:
+		archive, cleanup, err := bundle.SourceDirectory.BuildArchive()
+		if err != nil {
+			return diag.Errorf("failed to build archive from source directory: %v", err)
+		}
+		defer cleanup()
+
+		// The archive is rebuilt rather than carried over from the plan, so
+		// make sure it still matches what was planned.
+		checksum, err := archive.ChecksumSha256()
+		if err != nil {
+			return diag.Errorf("failed to calculate source archive checksum: %v", err)
+		}
+
+		if checksum != bundle.Data.ChecksumSha256 {
+			return diag.Errorf(
+				"the contents of source directory %q changed between plan and apply; run terraform plan again",
+				bundle.SourceDirectory.Path,
+			)
+		}
+
+		result, err := archive.Upload(ctx, client.(checkly.Client))
+		if err != nil {
+			return apiErrorDiagnostics(fmt.Errorf("failed to upload source archive: %w", err), d.GetRawConfig())
+		}
+
+		d.SetId(base64.StdEncoding.EncodeToString([]byte(result.Key)))
+
+		err = d.Set(sourceDirectoryAttributeName, bundle.SourceDirectory.ToList())
+		if err != nil {
+			return diag.Errorf("failed to set %q state: %v", sourceDirectoryAttributeName, err)
+		}
+
+		err = d.Set(metadataAttributeName, bundle.Data.EncodeToString())
+		if err != nil {
+			return diag.Errorf("failed to set %q state: %v", metadataAttributeName, err)
+		}
+
 		return nil
 	default:
 		return diag.Errorf("bundle has no source")
@@ -199,9 +423,6 @@ func resourcePlaywrightCodeBundleRead(
 	d *schema.ResourceData,
 	client any,
 ) (diags diag.Diagnostics) {
-	ctx, cancel := context.WithTimeout(ctx, apiCallTimeout())
-	defer cancel()
-
 	key, err := base64.StdEncoding.DecodeString(d.Id())
 	if err != nil {
 		return diag.Errorf("failed to thaw code bundle from resource data: %v", err)
@@ -214,12 +435,12 @@ func resourcePlaywrightCodeBundleRead(
 
 	result, err := client.(checkly.Client).PeekCodeBundle(ctx, string(key))
 	if err != nil {
-		if errors.Is(err, checkly.ErrCodeBundleNotFound) {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return diag.Errorf("failed to peek code bundle: %v", err)
+		return apiErrorDiagnostics(fmt.Errorf("failed to peek code bundle: %w", err), d.GetRawConfig())
 	}
 
 	if result.ChecksumSha256 != "" {
@@ -234,6 +455,16 @@ func resourcePlaywrightCodeBundleRead(
 	return nil
 }
 
+func resourcePlaywrightCodeBundleUpdate(
+	ctx context.Context,
+	d *schema.ResourceData,
+	client any,
+) (diags diag.Diagnostics) {
+	// Only the archive policy can change without replacing the bundle, and
+	// it is only used locally during plan.
+	return diags
+}
+
 func resourcePlaywrightCodeBundleDelete(
 	ctx context.Context,
 	d *schema.ResourceData,
@@ -244,7 +475,7 @@ func resourcePlaywrightCodeBundleDelete(
 	return diags
 }
 
-const PlaywrightCodeBundleMetadataCurrentVersion = 6
+const PlaywrightCodeBundleMetadataCurrentVersion = 9
 
 type PlaywrightCodeBundleMetadata struct {
 	Version           int    `json:"v"`
@@ -257,6 +488,113 @@ type PlaywrightCodeBundleMetadata struct {
 	EngineVersion     string `json:"ev,omitempty"`
 	EngineRawVersion  string `json:"erv,omitempty"`
 	EngineSource      string `json:"es,omitempty"`
+	EngineRuleSet     string `json:"ers,omitempty"`
+	YarnBerry         bool   `json:"yb,omitempty"`
+	YarnPnP           bool   `json:"ypnp,omitempty"`
+
+	// CacheInputs breaks CacheHash down by input, so that a change of the
+	// cache hash can be explained.
+	CacheInputs *PlaywrightCodeBundleCacheInputs `json:"ci,omitempty"`
+}
+
+// cacheInputDigestLength is the number of hex digits kept of each cache
+// input digest. They only need to tell a change apart, and are stored in
+// every metadata value.
+const cacheInputDigestLength = 12
+
+// PlaywrightCodeBundleCacheInputs holds short digests of each group of
+// inputs to the cache hash.
+type PlaywrightCodeBundleCacheInputs struct {
+	Lockfile          string `json:"lf"`
+	LockfileDigest    string `json:"l"`
+	PackageJSONDigest string `json:"p"`
+	NpmrcDigest       string `json:"n"`
+	YarnDigest        string `json:"y,omitempty"`
+}
+
+// describePlaywrightCodeBundleMetadataChanges lists the differences between
+// two metadata values in a form suitable for a plan reviewer.
+func describePlaywrightCodeBundleMetadataChanges(prev, next *PlaywrightCodeBundleMetadata) []string {
+	changes := []string{}
+
+	describe := func(label, from, to string) {
+		if from == to {
+			return
+		}
+		if from == "" {
+			from = "none"
+		}
+		if to == "" {
+			to = "none"
+		}
+		changes = append(changes, fmt.Sprintf("%s %s → %s", label, from, to))
+	}
+
+	if prev.Version != next.Version {
+		changes = append(changes, fmt.Sprintf("metadata format v%d → v%d", prev.Version, next.Version))
+	}
+
+	if prev.ChecksumSha256 != next.ChecksumSha256 {
+		changes = append(changes, "archive contents changed")
+	}
+
+	describe("playwright", prev.PlaywrightVersion, next.PlaywrightVersion)
+	describe("package manager", prev.PackageManager, next.PackageManager)
+
+	if prev.CacheHash != next.CacheHash {
+		if reasons := describeCacheInputChanges(prev.CacheInputs, next.CacheInputs); len(reasons) > 0 {
+			changes = append(changes, "cache hash changed because "+strings.Join(reasons, ", "))
+		} else {
+			changes = append(changes, "cache hash changed")
+		}
+	}
+
+	describe("working directory", prev.WorkingDir, next.WorkingDir)
+
+	engine := func(m *PlaywrightCodeBundleMetadata) string {
+		return strings.TrimSpace(m.Engine + " " + m.EngineVersion)
+	}
+	describe("engine", engine(prev), engine(next))
+
+	engineSource := func(m *PlaywrightCodeBundleMetadata) string {
+		if m.EngineSource == "" {
+			return ""
+		}
+		return fmt.Sprintf("%q from %s", m.EngineRawVersion, m.EngineSource)
+	}
+	describe("engine version", engineSource(prev), engineSource(next))
+
+	describe("engine rules", prev.EngineRuleSet, next.EngineRuleSet)
+	describe("yarn berry", fmt.Sprint(prev.YarnBerry), fmt.Sprint(next.YarnBerry))
+	describe("yarn plug'n'play", fmt.Sprint(prev.YarnPnP), fmt.Sprint(next.YarnPnP))
+
+	return changes
+}
+
+// describeCacheInputChanges names the inputs that differ between prev and
+// next. It returns nothing if either side has no cache inputs recorded.
+func describeCacheInputChanges(prev, next *PlaywrightCodeBundleCacheInputs) []string {
+	if prev == nil || next == nil {
+		return nil
+	}
+
+	var reasons []string
+	switch {
+	case prev.Lockfile != next.Lockfile:
+		reasons = append(reasons, fmt.Sprintf("the lockfile changed from %s to %s", prev.Lockfile, next.Lockfile))
+	case prev.LockfileDigest != next.LockfileDigest:
+		reasons = append(reasons, next.Lockfile+" changed")
+	}
+	if prev.PackageJSONDigest != next.PackageJSONDigest {
+		reasons = append(reasons, "package.json files changed")
+	}
+	if prev.NpmrcDigest != next.NpmrcDigest {
+		reasons = append(reasons, ".npmrc files changed")
+	}
+	if prev.YarnDigest != next.YarnDigest {
+		reasons = append(reasons, "the Yarn configuration changed")
+	}
+	return reasons
 }
 
 func PlaywrightCodeBundleMetadataFromString(s string) (*PlaywrightCodeBundleMetadata, error) {
@@ -296,9 +634,12 @@ func (t *PlaywrightCodeBundleMetadata) EncodeToString() string {
 }
 
 type PlaywrightCodeBundleResource struct {
-	ID              string
-	Data            *PlaywrightCodeBundleMetadata
-	PrebuiltArchive *PlaywrightCodeBundlePrebuiltArchiveAttribute
+	ID                string
+	Data              *PlaywrightCodeBundleMetadata
+	PrebuiltArchive   *PlaywrightCodeBundlePrebuiltArchiveAttribute
+	SourceDirectory   *PlaywrightCodeBundleSourceDirectoryAttribute
+	WorkingDirOptions WorkingDirOptions
+	ArchivePolicy     *PlaywrightCodeBundleArchivePolicy
 }
 
 func PlaywrightCodeBundleResourceFromResourceData(
@@ -309,6 +650,16 @@ func PlaywrightCodeBundleResourceFromResourceData(
 		return PlaywrightCodeBundleResource{}, err
 	}
 
+	sourceDirectoryAttr, err := PlaywrightCodeBundleSourceDirectoryAttributeFromList(d.Get(sourceDirectoryAttributeName).([]any))
+	if err != nil {
+		return PlaywrightCodeBundleResource{}, err
+	}
+
+	archivePolicyAttr, err := PlaywrightCodeBundleArchivePolicyFromList(d.Get(archivePolicyAttributeName).([]any))
+	if err != nil {
+		return PlaywrightCodeBundleResource{}, err
+	}
+
 	data, err := PlaywrightCodeBundleMetadataFromString(d.Get(metadataAttributeName).(string))
 	if err != nil {
 		return PlaywrightCodeBundleResource{}, err
@@ -318,6 +669,12 @@ func PlaywrightCodeBundleResourceFromResourceData(
 		ID:              d.Id(),
 		Data:            data,
 		PrebuiltArchive: prebuiltArchiveAttr,
+		SourceDirectory: sourceDirectoryAttr,
+		WorkingDirOptions: WorkingDirOptions{
+			ProjectPath:      d.Get(projectPathAttributeName).(string),
+			PlaywrightConfig: d.Get(playwrightConfigAttributeName).(string),
+		},
+		ArchivePolicy: archivePolicyAttr,
 	}
 
 	return resource, nil
@@ -331,6 +688,16 @@ func PlaywrightCodeBundleResourceFromResourceDiff(
 		return PlaywrightCodeBundleResource{}, err
 	}
 
+	sourceDirectoryAttr, err := PlaywrightCodeBundleSourceDirectoryAttributeFromList(d.Get(sourceDirectoryAttributeName).([]any))
+	if err != nil {
+		return PlaywrightCodeBundleResource{}, err
+	}
+
+	archivePolicyAttr, err := PlaywrightCodeBundleArchivePolicyFromList(d.Get(archivePolicyAttributeName).([]any))
+	if err != nil {
+		return PlaywrightCodeBundleResource{}, err
+	}
+
 	data, err := PlaywrightCodeBundleMetadataFromString(d.Get(metadataAttributeName).(string))
 	if err != nil {
 		return PlaywrightCodeBundleResource{}, err
@@ -340,6 +707,12 @@ func PlaywrightCodeBundleResourceFromResourceDiff(
 		ID:              d.Id(),
 		Data:            data,
 		PrebuiltArchive: prebuiltArchiveAttr,
+		SourceDirectory: sourceDirectoryAttr,
+		WorkingDirOptions: WorkingDirOptions{
+			ProjectPath:      d.Get(projectPathAttributeName).(string),
+			PlaywrightConfig: d.Get(playwrightConfigAttributeName).(string),
+		},
+		ArchivePolicy: archivePolicyAttr,
 	}
 
 	return resource, nil
@@ -377,6 +750,140 @@ func (a *PlaywrightCodeBundlePrebuiltArchiveAttribute) ToList() []tfMap {
 	}
 }
 
+type PlaywrightCodeBundleSourceDirectoryAttribute struct {
+	Path             string
+	Include          []string
+	Exclude          []string
+	RespectGitignore bool
+}
+
+func PlaywrightCodeBundleSourceDirectoryAttributeFromList(
+	list []any,
+) (*PlaywrightCodeBundleSourceDirectoryAttribute, error) {
+	if len(list) == 0 {
+		return nil, nil
+	}
+
+	m := list[0].(tfMap)
+
+	a := PlaywrightCodeBundleSourceDirectoryAttribute{
+		Path:             m["path"].(string),
+		Include:          stringsFromList(m["include"].([]any)),
+		Exclude:          stringsFromList(m["exclude"].([]any)),
+		RespectGitignore: m["respect_gitignore"].(bool),
+	}
+
+	return &a, nil
+}
+
+func (a *PlaywrightCodeBundleSourceDirectoryAttribute) ToList() []tfMap {
+	if a == nil {
+		return []tfMap{}
+	}
+
+	return []tfMap{
+		{
+			"path":              a.Path,
+			"include":           a.Include,
+			"exclude":           a.Exclude,
+			"respect_gitignore": a.RespectGitignore,
+		},
+	}
+}
+
+// BuildArchive packs the source directory into a temporary tar.gz archive
+// and returns it as a prebuilt archive, so that every inspection available
+// for prebuilt archives applies to it unchanged. The returned function
+// removes the temporary archive and must always be called.
+func (a *PlaywrightCodeBundleSourceDirectoryAttribute) BuildArchive() (
+	*PlaywrightCodeBundlePrebuiltArchiveAttribute,
+	func(),
+	error,
+) {
+	file, err := os.CreateTemp("", "checkly-code-bundle-*.tar.gz")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create temporary archive file: %w", err)
+	}
+
+	cleanup := func() {
+		_ = os.Remove(file.Name())
+	}
+
+	err = buildArchiveFromDirectory(a.Path, file, archiveBuilderOptions{
+		Include:          a.Include,
+		Exclude:          a.Exclude,
+		RespectGitignore: a.RespectGitignore,
+	})
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write temporary archive file: %w", closeErr)
+	}
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	archive := PlaywrightCodeBundlePrebuiltArchiveAttribute{
+		File: file.Name(),
+	}
+
+	return &archive, cleanup, nil
+}
+
+// PlaywrightCodeBundleInspection is what the provider detects in a code
+// bundle archive without talking to the API.
+type PlaywrightCodeBundleInspection struct {
+	Lockfile   *LockfileInfo
+	WorkingDir string
+}
+
+// Inspect runs the lockfile, engine and working directory detection that
+// backs the metadata of a code bundle. Unlike InspectLockfile, it fails when
+// the archive has no lockfile or the lockfile lacks @playwright/test, since
+// such an archive cannot be run as a Playwright Check Suite. The engine
+// version is resolved with engineRules, or the embedded rules if it is nil.
+func (a *PlaywrightCodeBundlePrebuiltArchiveAttribute) Inspect(
+	workingDirOpts WorkingDirOptions,
+	engineRules *engineRuleSet,
+) (*PlaywrightCodeBundleInspection, error) {
+	lockfileInfo, err := a.InspectLockfile("@playwright/test", InspectLockfileOptions{
+		EngineRules: engineRules,
+		PackageJSONExcludedFields: []string{
+			// Exclude "version" because CI workflows often stamp it with a
+			// commit hash or build number. Including it would invalidate the
+			// dependency cache on every build even when no dependencies
+			// actually changed.
+			"version",
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect lockfile in archive: %w", err)
+	}
+
+	if lockfileInfo == nil {
+		return nil, fmt.Errorf(
+			"no lockfile found at the root of the archive; " +
+				"the archive must contain a package-lock.json, pnpm-lock.yaml, yarn.lock, or bun.lock at the root level",
+		)
+	}
+
+	if lockfileInfo.PackageVersion == "" {
+		return nil, fmt.Errorf(
+			"the lockfile does not contain @playwright/test; " +
+				"add @playwright/test to the project's dependencies and regenerate the lockfile",
+		)
+	}
+
+	workingDir, err := a.DetectWorkingDir(workingDirOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect working directory in archive: %v", err)
+	}
+
+	return &PlaywrightCodeBundleInspection{
+		Lockfile:   lockfileInfo,
+		WorkingDir: workingDir,
+	}, nil
+}
+
 func (a *PlaywrightCodeBundlePrebuiltArchiveAttribute) ChecksumSha256() (string, error) {
 	file, err := os.Open(a.File)
 	if err != nil {
@@ -394,10 +901,24 @@ type LockfileInfo struct {
 	PackageManager   string
 	PackageVersion   string
 	ChecksumSha256   string
+	CacheInputs      *PlaywrightCodeBundleCacheInputs
 	Engine           string
 	EngineVersion    string
 	EngineRawVersion string
 	EngineSource     string
+	EngineNotices    []string
+
+	// EngineRuleSet identifies the engine rules the engine version was
+	// resolved with. See engineRuleSet.ID.
+	EngineRuleSet string
+
+	// YarnBerry is set when the lockfile is a Yarn Berry (v2+) lockfile.
+	YarnBerry bool
+
+	// YarnPnP is set when a Yarn Berry project installs with Plug'n'Play,
+	// either because the archive has a .pnp.cjs or because the root
+	// .yarnrc.yml selects (or defaults to) the pnp linker.
+	YarnPnP bool
 }
 
 type lockfileParser struct {
@@ -426,6 +947,10 @@ type InspectLockfileOptions struct {
 	// package.json before it contributes to ChecksumSha256. Useful for
 	// fields that don't affect runtime behavior, like "version".
 	PackageJSONExcludedFields []string
+
+	// EngineRules are the rules the engine version is resolved with. The
+	// rules embedded in the provider are used if it is nil.
+	EngineRules *engineRuleSet
 }
 
 type packageJSONEntry struct {
@@ -438,6 +963,11 @@ type npmrcEntry struct {
 	raw  []byte
 }
 
+type yarnFileEntry struct {
+	path string
+	raw  []byte
+}
+
 // InspectLockfile opens the tar.gz archive and searches for a lockfile
 // (package-lock.json, pnpm-lock.yaml, yarn.lock, or bun.lock) at the root
 // of the archive. If found, it returns the detected package manager and
@@ -449,6 +979,10 @@ type npmrcEntry struct {
 // removed from the top level, so cosmetic changes and excluded fields don't
 // influence the checksum. .npmrc files contribute the raw SHA-256 of their
 // bytes, so any change to registry configuration invalidates the checksum.
+//
+// For Yarn Berry lockfiles the checksum additionally covers the linker in use
+// (pnp or node-modules), every .yarnrc.yml outside node_modules, and the patch
+// files under .yarn/patches that `patch:` resolutions point to.
 func (a *PlaywrightCodeBundlePrebuiltArchiveAttribute) InspectLockfile(
 	packageName string,
 	opts InspectLockfileOptions,
@@ -475,6 +1009,10 @@ func (a *PlaywrightCodeBundlePrebuiltArchiveAttribute) InspectLockfile(
 		packageVersion string
 		packageJSONs   []packageJSONEntry
 		npmrcs         []npmrcEntry
+		yarnrcs        []yarnFileEntry
+		yarnPatches    []yarnFileEntry
+		yarnLock       []byte
+		sawPnP         bool
 		engineFiles    = make(map[string][]byte)
 	)
 
@@ -511,13 +1049,36 @@ func (a *PlaywrightCodeBundlePrebuiltArchiveAttribute) InspectLockfile(
 			continue
 		}
 
+		if path.Base(name) == ".yarnrc.yml" && !hasNodeModulesSegment(name) {
+			raw, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %q from archive %q: %w", header.Name, a.File, err)
+			}
+			yarnrcs = append(yarnrcs, yarnFileEntry{path: name, raw: raw})
+			continue
+		}
+
+		if strings.HasPrefix(name, ".yarn/patches/") {
+			raw, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %q from archive %q: %w", header.Name, a.File, err)
+			}
+			yarnPatches = append(yarnPatches, yarnFileEntry{path: name, raw: raw})
+			continue
+		}
+
 		// Only consider lockfiles and engine version files at the root of the archive.
 		if strings.Contains(name, "/") {
 			continue
 		}
 
+		if name == ".pnp.cjs" {
+			sawPnP = true
+			continue
+		}
+
 		switch name {
-		case ".node-version", ".nvmrc", ".tool-versions", ".bun-version":
+		case ".node-version", ".nvmrc", ".tool-versions", ".bun-version", "mise.toml", ".mise.toml":
 			raw, err := io.ReadAll(tr)
 			if err != nil {
 				return nil, fmt.Errorf("failed to read %q from archive %q: %w", header.Name, a.File, err)
@@ -541,9 +1102,15 @@ func (a *PlaywrightCodeBundlePrebuiltArchiveAttribute) InspectLockfile(
 			continue
 		}
 
-		// Hash the lockfile content as it flows through the parser.
+		// Hash the lockfile content as it flows through the parser. A
+		// yarn.lock is also kept to tell Berry lockfiles from classic ones.
 		hash := sha256.New()
-		tee := io.TeeReader(tr, hash)
+		var sink io.Writer = hash
+		var captured bytes.Buffer
+		if name == "yarn.lock" {
+			sink = io.MultiWriter(hash, &captured)
+		}
+		tee := io.TeeReader(tr, sink)
 
 		version, err := parser.parse(tee, packageName)
 		if err != nil {
@@ -560,6 +1127,9 @@ func (a *PlaywrightCodeBundlePrebuiltArchiveAttribute) InspectLockfile(
 		lockfileHash = hash.Sum(nil)
 		packageManager = parser.packageManager
 		packageVersion = version
+		if name == "yarn.lock" {
+			yarnLock = captured.Bytes()
+		}
 	}
 
 	if lockfileName == "" {
@@ -569,7 +1139,23 @@ func (a *PlaywrightCodeBundlePrebuiltArchiveAttribute) InspectLockfile(
 		return nil, nil
 	}
 
-	checksum, err := composeBundleChecksum(lockfileName, lockfileHash, packageJSONs, npmrcs, opts.PackageJSONExcludedFields)
+	var yarn *yarnChecksumInputs
+	if lockfileName == "yarn.lock" && isYarnBerryLock(yarnLock) {
+		linker, err := yarnNodeLinker(yarnrcs)
+		if err != nil {
+			return nil, err
+		}
+		if sawPnP {
+			linker = "pnp"
+		}
+		yarn = &yarnChecksumInputs{
+			linker:  linker,
+			yarnrcs: yarnrcs,
+			patches: yarnPatches,
+		}
+	}
+
+	checksum, cacheInputs, err := composeBundleChecksum(lockfileName, lockfileHash, packageJSONs, npmrcs, yarn, opts.PackageJSONExcludedFields)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compute archive checksum: %w", err)
 	}
@@ -582,18 +1168,39 @@ func (a *PlaywrightCodeBundlePrebuiltArchiveAttribute) InspectLockfile(
 		}
 	}
 
-	engineResult := detectEngine(engineFiles, packageManager)
+	engineRules := opts.EngineRules
+	if engineRules == nil {
+		engineRules = embeddedEngineRuleSet
+	}
+
+	engineResult := detectEngine(engineRules, engineFiles, packageManager)
+
+	if notice := packageManagerFieldNotice(engineFiles["package.json"], packageManager, yarn != nil); notice != "" {
+		if engineResult == nil {
+			engineResult = &EngineDetectionResult{RuleSet: engineRules.ID}
+		}
+		engineResult.Notices = append(engineResult.Notices, notice)
+	}
 
 	info := &LockfileInfo{
 		PackageManager: packageManager,
 		PackageVersion: packageVersion,
 		ChecksumSha256: checksum,
+		CacheInputs:    cacheInputs,
+	}
+	if yarn != nil {
+		info.YarnBerry = true
+		info.YarnPnP = yarn.linker == "pnp"
 	}
 	if engineResult != nil && engineResult.Engine != nil {
 		info.Engine = engineResult.Engine.Name
 		info.EngineVersion = engineResult.Engine.Version
 		info.EngineRawVersion = engineResult.RawVersion
 		info.EngineSource = engineResult.Source
+		info.EngineRuleSet = engineResult.RuleSet
+	}
+	if engineResult != nil {
+		info.EngineNotices = engineResult.Notices
 	}
 	return info, nil
 }
@@ -633,8 +1240,9 @@ func composeBundleChecksum(
 	lockfileHash []byte,
 	packageJSONs []packageJSONEntry,
 	npmrcs []npmrcEntry,
+	yarn *yarnChecksumInputs,
 	excludedFields []string,
-) (string, error) {
+) (string, *PlaywrightCodeBundleCacheInputs, error) {
 	sort.Slice(packageJSONs, func(i, j int) bool {
 		return packageJSONs[i].path < packageJSONs[j].path
 	})
@@ -643,32 +1251,107 @@ func composeBundleChecksum(
 	})
 
 	h := sha256.New()
+
+	// Every record also goes into the digest of its group, which is what
+	// the cache inputs are made of.
+	var group hash.Hash
 	writeRecord := func(label string, content []byte) {
+		w := io.MultiWriter(h, group)
 		var lenBuf [8]byte
 		binary.BigEndian.PutUint64(lenBuf[:], uint64(len(label)))
-		h.Write(lenBuf[:])
-		h.Write([]byte(label))
+		w.Write(lenBuf[:])
+		w.Write([]byte(label))
 		binary.BigEndian.PutUint64(lenBuf[:], uint64(len(content)))
-		h.Write(lenBuf[:])
-		h.Write(content)
+		w.Write(lenBuf[:])
+		w.Write(content)
+	}
+	groupDigest := func() string {
+		return hex.EncodeToString(group.Sum(nil))[:cacheInputDigestLength]
+	}
+
+	inputs := &PlaywrightCodeBundleCacheInputs{
+		Lockfile: lockfileName,
 	}
 
+	group = sha256.New()
 	writeRecord("lockfile:"+lockfileName, lockfileHash)
+	inputs.LockfileDigest = groupDigest()
 
+	group = sha256.New()
 	for _, entry := range packageJSONs {
 		canonical, err := canonicalizePackageJSON(entry.raw, excludedFields)
 		if err != nil {
-			return "", fmt.Errorf("failed to canonicalize %q: %w", entry.path, err)
+			return "", nil, fmt.Errorf("failed to canonicalize %q: %w", entry.path, err)
 		}
 		writeRecord("package.json:"+entry.path, canonical)
 	}
+	inputs.PackageJSONDigest = groupDigest()
 
+	group = sha256.New()
 	for _, entry := range npmrcs {
 		sum := sha256.Sum256(entry.raw)
 		writeRecord("npmrc:"+entry.path, sum[:])
 	}
+	inputs.NpmrcDigest = groupDigest()
 
-	return hex.EncodeToString(h.Sum(nil)), nil
+	// Yarn records come last and are only written for Berry lockfiles, so
+	// every other bundle keeps the checksum the Checkly CLI computes for it.
+	if yarn != nil {
+		group = sha256.New()
+		writeRecord("yarn-linker", []byte(yarn.linker))
+
+		for _, entry := range sortedYarnFiles(yarn.yarnrcs) {
+			sum := sha256.Sum256(entry.raw)
+			writeRecord("yarnrc:"+entry.path, sum[:])
+		}
+
+		for _, entry := range sortedYarnFiles(yarn.patches) {
+			sum := sha256.Sum256(entry.raw)
+			writeRecord("yarn-patch:"+entry.path, sum[:])
+		}
+		inputs.YarnDigest = groupDigest()
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), inputs, nil
+}
+
+// yarnChecksumInputs holds the Yarn Berry specific inputs of the bundle
+// checksum.
+type yarnChecksumInputs struct {
+	linker  string
+	yarnrcs []yarnFileEntry
+	patches []yarnFileEntry
+}
+
+func sortedYarnFiles(entries []yarnFileEntry) []yarnFileEntry {
+	sorted := slices.Clone(entries)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].path < sorted[j].path
+	})
+	return sorted
+}
+
+// yarnNodeLinker returns the nodeLinker configured in the root .yarnrc.yml.
+// Yarn Berry defaults to Plug'n'Play when none is set.
+func yarnNodeLinker(yarnrcs []yarnFileEntry) (string, error) {
+	for _, entry := range yarnrcs {
+		if entry.path != ".yarnrc.yml" {
+			continue
+		}
+
+		var yarnrc struct {
+			NodeLinker string `yaml:"nodeLinker"`
+		}
+		if err := yaml.Unmarshal(entry.raw, &yarnrc); err != nil {
+			return "", fmt.Errorf("failed to parse .yarnrc.yml: %w", err)
+		}
+
+		if yarnrc.NodeLinker != "" {
+			return yarnrc.NodeLinker, nil
+		}
+	}
+
+	return "pnp", nil
 }
 
 var playwrightConfigExtensions = map[string]bool{
@@ -695,10 +1378,32 @@ func isPlaywrightConfig(name string) bool {
 	return playwrightConfigExtensions[ext]
 }
 
-// DetectWorkingDir scans the archive for a Playwright config file and
-// returns the directory of the closest ancestor package.json. If the
-// config is at the root or no config is found, it returns an empty string.
-func (a *PlaywrightCodeBundlePrebuiltArchiveAttribute) DetectWorkingDir() (string, error) {
+// WorkingDirOptions selects the Playwright project in an archive that holds
+// more than one. At most one of the fields should be set.
+type WorkingDirOptions struct {
+	// ProjectPath is the directory of the project, relative to the archive
+	// root. It must contain a package.json.
+	ProjectPath string
+
+	// PlaywrightConfig is the path of the Playwright config file, relative
+	// to the archive root. The project is the closest directory above it
+	// that contains a package.json.
+	PlaywrightConfig string
+}
+
+// DetectWorkingDir returns the directory of the Playwright project in the
+// archive, relative to the archive root.
+//
+// Unless opts selects a project explicitly, the archive is scanned for
+// Playwright config files outside node_modules. Each config belongs to the
+// closest directory above it with a package.json; when the archive declares
+// workspaces (in pnpm-workspace.yaml or the "workspaces" field of the root
+// package.json), only the workspace root and its member packages count as
+// projects. In a workspace every config is a candidate, otherwise only the
+// least nested ones are. Candidates belonging to different projects are an
+// error, since picking one would be a guess. Without any config, the archive
+// root is returned.
+func (a *PlaywrightCodeBundlePrebuiltArchiveAttribute) DetectWorkingDir(opts WorkingDirOptions) (string, error) {
 	file, err := os.Open(a.File)
 	if err != nil {
 		return "", fmt.Errorf("failed to open archive file %q: %w", a.File, err)
@@ -713,8 +1418,13 @@ func (a *PlaywrightCodeBundlePrebuiltArchiveAttribute) DetectWorkingDir() (strin
 
 	tr := tar.NewReader(gzr)
 
-	var configPaths []string
-	packageJSONDirs := map[string]bool{}
+	var (
+		configPaths     []string
+		files           = map[string]bool{}
+		packageJSONDirs = map[string]bool{}
+		rootPackageJSON []byte
+		pnpmWorkspace   []byte
+	)
 
 	for {
 		header, err := tr.Next()
@@ -730,42 +1440,146 @@ func (a *PlaywrightCodeBundlePrebuiltArchiveAttribute) DetectWorkingDir() (strin
 		}
 
 		name := strings.TrimPrefix(header.Name, "./")
+		files[name] = true
+
+		if hasNodeModulesSegment(name) {
+			continue
+		}
 
 		if isPlaywrightConfig(name) {
 			configPaths = append(configPaths, name)
 		}
 
-		if path.Base(name) == "package.json" {
+		switch {
+		case name == "package.json":
+			rootPackageJSON, err = io.ReadAll(tr)
+			if err != nil {
+				return "", fmt.Errorf("failed to read %q from archive %q: %w", header.Name, a.File, err)
+			}
+			packageJSONDirs["."] = true
+		case path.Base(name) == "package.json":
 			packageJSONDirs[path.Dir(name)] = true
+		case name == "pnpm-workspace.yaml":
+			pnpmWorkspace, err = io.ReadAll(tr)
+			if err != nil {
+				return "", fmt.Errorf("failed to read %q from archive %q: %w", header.Name, a.File, err)
+			}
 		}
 	}
 
-	if len(configPaths) == 0 {
-		return ".", nil
+	var workspaceGlobs []string
+	if pnpmWorkspace != nil {
+		if workspaceGlobs, err = parsePnpmWorkspacePackages(pnpmWorkspace); err != nil {
+			return "", err
+		}
+	} else if rootPackageJSON != nil {
+		if workspaceGlobs, err = parsePackageJSONWorkspaces(rootPackageJSON); err != nil {
+			return "", err
+		}
 	}
 
-	// Pick the config with the shortest path. Break ties lexicographically.
-	shortest := configPaths[0]
-	for _, p := range configPaths[1:] {
-		if len(p) < len(shortest) || (len(p) == len(shortest) && p < shortest) {
-			shortest = p
+	var workspace *workspaceMatcher
+	if len(workspaceGlobs) > 0 {
+		if workspace, err = newWorkspaceMatcher(workspaceGlobs); err != nil {
+			return "", err
 		}
 	}
 
-	// Walk up from the config's directory to find the closest package.json.
-	dir := path.Dir(shortest)
-	for {
-		if packageJSONDirs[dir] {
-			return dir, nil
+	isProject := func(dir string) bool {
+		if !packageJSONDirs[dir] {
+			return false
+		}
+		return workspace == nil || dir == "." || workspace.matches(dir)
+	}
+
+	// Walk up from the config's directory to find the closest project.
+	projectOf := func(config string) string {
+		dir := path.Dir(config)
+		for {
+			if isProject(dir) {
+				return dir
+			}
+
+			parent := path.Dir(dir)
+			if parent == dir {
+				// Reached root without finding a project.
+				return "."
+			}
+			dir = parent
+		}
+	}
+
+	switch {
+	case opts.PlaywrightConfig != "":
+		config := cleanArchivePath(opts.PlaywrightConfig)
+		if !files[config] {
+			return "", fmt.Errorf(
+				"the Playwright config %q does not exist in the archive; %s",
+				config, describePlaywrightConfigs(configPaths),
+			)
+		}
+		return projectOf(config), nil
+	case opts.ProjectPath != "":
+		dir := cleanArchivePath(opts.ProjectPath)
+		if !packageJSONDirs[dir] {
+			return "", fmt.Errorf(
+				"the project path %q does not contain a package.json in the archive",
+				dir,
+			)
 		}
+		return dir, nil
+	}
+
+	if len(configPaths) == 0 {
+		return ".", nil
+	}
 
-		parent := path.Dir(dir)
-		if parent == dir {
-			// Reached root without finding package.json.
-			return ".", nil
+	candidates := configPaths
+	if workspace == nil {
+		// Outside of a workspace, prefer the least nested configs.
+		minDepth := -1
+		for _, p := range configPaths {
+			if depth := strings.Count(p, "/"); minDepth < 0 || depth < minDepth {
+				minDepth = depth
+			}
 		}
-		dir = parent
+
+		candidates = nil
+		for _, p := range configPaths {
+			if strings.Count(p, "/") == minDepth {
+				candidates = append(candidates, p)
+			}
+		}
+	}
+
+	projects := map[string]bool{}
+	for _, p := range candidates {
+		projects[projectOf(p)] = true
+	}
+
+	if len(projects) > 1 {
+		sort.Strings(candidates)
+		return "", fmt.Errorf(
+			"found Playwright configs in %d different projects of the archive, "+
+				"set project_path or playwright_config to choose one: %s",
+			len(projects), strings.Join(candidates, ", "),
+		)
+	}
+
+	return projectOf(candidates[0]), nil
+}
+
+// describePlaywrightConfigs lists the Playwright configs found in an archive
+// for use in error messages.
+func describePlaywrightConfigs(configPaths []string) string {
+	if len(configPaths) == 0 {
+		return "no Playwright config was found"
 	}
+
+	sorted := slices.Clone(configPaths)
+	sort.Strings(sorted)
+
+	return "found: " + strings.Join(sorted, ", ")
 }
 
 func (a *PlaywrightCodeBundlePrebuiltArchiveAttribute) Upload(
@@ -791,7 +1605,9 @@ func (a *PlaywrightCodeBundlePrebuiltArchiveAttribute) Upload(
 		return nil, fmt.Errorf("failed to open archive file %q: %w", a.File, err)
 	}
 
-	codeBundle, err := client.UploadCodeBundle(ctx, file, stat.Size(), checkly.UploadCodeBundleOptions{
+	// Large archives take longer to upload than an ordinary API request is
+	// allowed to; the upload is bounded by the create timeout instead.
+	codeBundle, err := client.UploadCodeBundle(withoutRequestTimeout(ctx), file, stat.Size(), checkly.UploadCodeBundleOptions{
 		ChecksumSha256: checksum,
 	})
 	if err != nil {
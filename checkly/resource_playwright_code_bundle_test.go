@@ -5,9 +5,12 @@ import (
 	"compress/gzip"
 	"crypto/sha256"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -163,7 +166,177 @@ func TestDetectWorkingDir(t *testing.T) {
 				File: tt.file,
 			}
 
-			got, err := attr.DetectWorkingDir()
+			got, err := attr.DetectWorkingDir(WorkingDirOptions{})
+			if err != nil {
+				t.Fatalf("DetectWorkingDir failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DetectWorkingDir() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectWorkingDirSelection(t *testing.T) {
+	t.Parallel()
+
+	pkg := func(name string) tarEntry {
+		return tarEntry{name: name, content: []byte(`{"name":"x"}`)}
+	}
+	file := func(name string) tarEntry {
+		return tarEntry{name: name, content: []byte("export default {}")}
+	}
+
+	twoProjects := []tarEntry{
+		{name: "package.json", content: []byte(`{"name":"root","workspaces":["packages/*"]}`)},
+		pkg("packages/a/package.json"),
+		file("packages/a/playwright.config.ts"),
+		pkg("packages/b/package.json"),
+		file("packages/b/playwright.config.ts"),
+	}
+
+	tests := []struct {
+		name    string
+		entries []tarEntry
+		opts    WorkingDirOptions
+		want    string
+		wantErr string
+	}{
+		{
+			name: "no config",
+			entries: []tarEntry{
+				pkg("package.json"),
+			},
+			want: ".",
+		},
+		{
+			name: "configs in node_modules are ignored",
+			entries: []tarEntry{
+				pkg("package.json"),
+				file("playwright.config.ts"),
+				pkg("node_modules/dep/package.json"),
+				file("node_modules/dep/playwright.config.ts"),
+			},
+			want: ".",
+		},
+		{
+			name: "without workspaces the least nested config wins",
+			entries: []tarEntry{
+				pkg("package.json"),
+				pkg("e2e/package.json"),
+				file("e2e/playwright.config.ts"),
+				pkg("e2e/nested/package.json"),
+				file("e2e/nested/deeper/playwright.config.ts"),
+			},
+			want: "e2e",
+		},
+		{
+			name: "without workspaces tied configs in different projects are ambiguous",
+			entries: []tarEntry{
+				pkg("package.json"),
+				pkg("apps/package.json"),
+				file("apps/playwright.config.ts"),
+				pkg("e2e/package.json"),
+				file("e2e/playwright.config.ts"),
+			},
+			wantErr: "found Playwright configs in 2 different projects of the archive, set project_path or playwright_config to choose one: apps/playwright.config.ts, e2e/playwright.config.ts",
+		},
+		{
+			name: "tied configs in the same project are not ambiguous",
+			entries: []tarEntry{
+				pkg("package.json"),
+				file("playwright.config.ts"),
+				file("playwright.smoke.config.ts"),
+			},
+			want: ".",
+		},
+		{
+			name:    "package.json workspaces with several projects are ambiguous",
+			entries: twoProjects,
+			wantErr: "packages/a/playwright.config.ts, packages/b/playwright.config.ts",
+		},
+		{
+			name: "pnpm workspace with several projects at different depths is ambiguous",
+			entries: []tarEntry{
+				pkg("package.json"),
+				{name: "pnpm-workspace.yaml", content: []byte("packages:\n  - 'apps/**'\n")},
+				file("playwright.config.ts"),
+				pkg("apps/web/e2e/package.json"),
+				file("apps/web/e2e/playwright.config.ts"),
+			},
+			wantErr: "apps/web/e2e/playwright.config.ts, playwright.config.ts",
+		},
+		{
+			name: "non-member package.json does not make a project",
+			entries: []tarEntry{
+				pkg("package.json"),
+				{name: "pnpm-workspace.yaml", content: []byte("packages:\n  - 'packages/*'\n")},
+				pkg("packages/e2e/package.json"),
+				pkg("packages/e2e/tests/fixture-app/package.json"),
+				file("packages/e2e/tests/fixture-app/playwright.config.ts"),
+			},
+			want: "packages/e2e",
+		},
+		{
+			name: "excluded workspace package does not make a project",
+			entries: []tarEntry{
+				pkg("package.json"),
+				{name: "pnpm-workspace.yaml", content: []byte("packages:\n  - 'packages/**'\n  - '!**/fixtures/**'\n")},
+				pkg("packages/e2e/package.json"),
+				pkg("packages/e2e/fixtures/app/package.json"),
+				file("packages/e2e/fixtures/app/playwright.config.ts"),
+			},
+			want: "packages/e2e",
+		},
+		{
+			name:    "project_path selects a project",
+			entries: twoProjects,
+			opts:    WorkingDirOptions{ProjectPath: "./packages/b/"},
+			want:    "packages/b",
+		},
+		{
+			name:    "project_path without package.json",
+			entries: twoProjects,
+			opts:    WorkingDirOptions{ProjectPath: "packages/c"},
+			wantErr: `the project path "packages/c" does not contain a package.json in the archive`,
+		},
+		{
+			name:    "playwright_config selects a project",
+			entries: twoProjects,
+			opts:    WorkingDirOptions{PlaywrightConfig: "packages/a/playwright.config.ts"},
+			want:    "packages/a",
+		},
+		{
+			name: "playwright_config with a custom name",
+			entries: append(slices.Clone(twoProjects),
+				file("packages/b/e2e.config.ts"),
+			),
+			opts: WorkingDirOptions{PlaywrightConfig: "packages/b/e2e.config.ts"},
+			want: "packages/b",
+		},
+		{
+			name:    "playwright_config that does not exist",
+			entries: twoProjects,
+			opts:    WorkingDirOptions{PlaywrightConfig: "packages/c/playwright.config.ts"},
+			wantErr: `the Playwright config "packages/c/playwright.config.ts" does not exist in the archive; found: packages/a/playwright.config.ts, packages/b/playwright.config.ts`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			attr := PlaywrightCodeBundlePrebuiltArchiveAttribute{
+				File: buildTarGz(t, tt.entries),
+			}
+
+			got, err := attr.DetectWorkingDir(tt.opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("DetectWorkingDir() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
 			if err != nil {
 				t.Fatalf("DetectWorkingDir failed: %v", err)
 			}
@@ -570,7 +743,7 @@ func TestComposeBundleChecksumNpmrcCrossLanguageParity(t *testing.T) {
 		wantNoNpmrc   = "dd362bae9c06091691f72b3a73e4f5f4d4861518629e85ed9fd08221d655f204"
 	)
 
-	got, err := composeBundleChecksum("package-lock.json", lockHash[:], pkgs, npmrcs, []string{"version"})
+	got, _, err := composeBundleChecksum("package-lock.json", lockHash[:], pkgs, npmrcs, nil, []string{"version"})
 	if err != nil {
 		t.Fatalf("composeBundleChecksum: %v", err)
 	}
@@ -580,7 +753,7 @@ func TestComposeBundleChecksumNpmrcCrossLanguageParity(t *testing.T) {
 
 	// Omitting .npmrc must be a no-op relative to a bundle that never had one,
 	// matching the CLI (which writes zero npmrc records when there are none).
-	gotNoNpmrc, err := composeBundleChecksum("package-lock.json", lockHash[:], pkgs, nil, []string{"version"})
+	gotNoNpmrc, _, err := composeBundleChecksum("package-lock.json", lockHash[:], pkgs, nil, nil, []string{"version"})
 	if err != nil {
 		t.Fatalf("composeBundleChecksum: %v", err)
 	}
@@ -738,6 +911,45 @@ func TestInspectLockfileDetectsEngine(t *testing.T) {
 		}
 	})
 
+	t.Run("mise.toml selects node", func(t *testing.T) {
+		t.Parallel()
+		archive := buildTarGz(t, []tarEntry{
+			{name: "package-lock.json", content: []byte(syntheticPackageLock)},
+			{name: "package.json", content: []byte(`{"name":"test","dependencies":{"@playwright/test":"1.58.2"}}`)},
+			{name: "mise.toml", content: []byte("[tools]\nnode = \"24.1.0\"\n")},
+		})
+		attr := PlaywrightCodeBundlePrebuiltArchiveAttribute{File: archive}
+		info, err := attr.InspectLockfile("@playwright/test", InspectLockfileOptions{})
+		if err != nil {
+			t.Fatalf("InspectLockfile failed: %v", err)
+		}
+		if info.EngineVersion != "24" {
+			t.Errorf("EngineVersion = %q, want %q", info.EngineVersion, "24")
+		}
+		if info.EngineSource != "mise.toml" {
+			t.Errorf("EngineSource = %q, want %q", info.EngineSource, "mise.toml")
+		}
+	})
+
+	t.Run("packageManager mismatch is a notice", func(t *testing.T) {
+		t.Parallel()
+		archive := buildTarGz(t, []tarEntry{
+			{name: "package-lock.json", content: []byte(syntheticPackageLock)},
+			{name: "package.json", content: []byte(`{"name":"test","packageManager":"pnpm@9.1.0"}`)},
+		})
+		attr := PlaywrightCodeBundlePrebuiltArchiveAttribute{File: archive}
+		info, err := attr.InspectLockfile("@playwright/test", InspectLockfileOptions{})
+		if err != nil {
+			t.Fatalf("InspectLockfile() failed: %v", err)
+		}
+		if info.PackageManager != "npm" {
+			t.Errorf("PackageManager = %q, want %q", info.PackageManager, "npm")
+		}
+		if len(info.EngineNotices) != 1 || !strings.Contains(info.EngineNotices[0], `declares packageManager "pnpm@9.1.0"`) {
+			t.Errorf("EngineNotices = %q, want a packageManager mismatch", info.EngineNotices)
+		}
+	})
+
 	t.Run("no version file leaves engine empty", func(t *testing.T) {
 		t.Parallel()
 		archive := buildTarGz(t, []tarEntry{
@@ -796,3 +1008,308 @@ func TestInspectLockfileDetectsEngine(t *testing.T) {
 		}
 	})
 }
+
+const syntheticYarnBerryLock = `__metadata:
+  version: 8
+  cacheKey: 10c0
+
+"@playwright/test@npm:^1.58.0":
+  version: 1.58.2
+  resolution: "@playwright/test@npm:1.58.2"
+  languageName: node
+  linkType: hard
+`
+
+func TestInspectLockfileYarnBerry(t *testing.T) {
+	t.Parallel()
+
+	inspect := func(t *testing.T, entries []tarEntry) *LockfileInfo {
+		t.Helper()
+
+		attr := PlaywrightCodeBundlePrebuiltArchiveAttribute{
+			File: buildTarGz(t, entries),
+		}
+
+		info, err := attr.InspectLockfile("@playwright/test", InspectLockfileOptions{})
+		if err != nil {
+			t.Fatalf("InspectLockfile failed: %v", err)
+		}
+		if info == nil {
+			t.Fatal("InspectLockfile returned nil")
+		}
+		return info
+	}
+
+	base := []tarEntry{
+		{name: "package.json", content: []byte(`{"name":"root"}`)},
+		{name: "yarn.lock", content: []byte(syntheticYarnBerryLock)},
+	}
+
+	with := func(extra ...tarEntry) []tarEntry {
+		return append(slices.Clone(base), extra...)
+	}
+
+	tests := []struct {
+		name      string
+		entries   []tarEntry
+		wantBerry bool
+		wantPnP   bool
+	}{
+		{
+			name: "classic lockfile",
+			entries: []tarEntry{
+				{name: "package.json", content: []byte(`{"name":"root"}`)},
+				{name: "yarn.lock", content: []byte("\"@playwright/test@^1.58.0\":\n  version \"1.58.2\"\n")},
+			},
+		},
+		{
+			name:      "berry defaults to pnp",
+			entries:   base,
+			wantBerry: true,
+			wantPnP:   true,
+		},
+		{
+			name:      "berry with node-modules linker",
+			entries:   with(tarEntry{name: ".yarnrc.yml", content: []byte("nodeLinker: node-modules\n")}),
+			wantBerry: true,
+		},
+		{
+			name: "berry with .pnp.cjs",
+			entries: with(
+				tarEntry{name: ".yarnrc.yml", content: []byte("nodeLinker: node-modules\n")},
+				tarEntry{name: ".pnp.cjs", content: []byte("// generated")},
+			),
+			wantBerry: true,
+			wantPnP:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			info := inspect(t, tt.entries)
+			if info.PackageManager != "yarn" {
+				t.Errorf("PackageManager = %q, want %q", info.PackageManager, "yarn")
+			}
+			if info.PackageVersion != "1.58.2" {
+				t.Errorf("PackageVersion = %q, want %q", info.PackageVersion, "1.58.2")
+			}
+			if info.YarnBerry != tt.wantBerry {
+				t.Errorf("YarnBerry = %v, want %v", info.YarnBerry, tt.wantBerry)
+			}
+			if info.YarnPnP != tt.wantPnP {
+				t.Errorf("YarnPnP = %v, want %v", info.YarnPnP, tt.wantPnP)
+			}
+		})
+	}
+
+	t.Run("checksum covers yarn configuration", func(t *testing.T) {
+		t.Parallel()
+
+		plain := inspect(t, base).ChecksumSha256
+
+		variants := map[string][]tarEntry{
+			".yarnrc.yml":   with(tarEntry{name: ".yarnrc.yml", content: []byte("npmRegistryServer: https://npm.example.com\n")}),
+			"patch file":    with(tarEntry{name: ".yarn/patches/@playwright-test-npm-1.58.2-abc.patch", content: []byte("--- a\n+++ b\n")}),
+			"linker":        with(tarEntry{name: ".yarnrc.yml", content: []byte("nodeLinker: node-modules\n")}),
+			"nested yarnrc": with(tarEntry{name: "packages/e2e/.yarnrc.yml", content: []byte("enableGlobalCache: false\n")}),
+		}
+
+		for name, entries := range variants {
+			if got := inspect(t, entries).ChecksumSha256; got == plain {
+				t.Errorf("adding %s should change the checksum", name)
+			}
+		}
+
+		// Plug'n'Play output is generated from the lockfile, so it must not
+		// affect the checksum when pnp is already the linker.
+		if got := inspect(t, with(tarEntry{name: ".pnp.cjs", content: []byte("// generated")})).ChecksumSha256; got != plain {
+			t.Error(".pnp.cjs should not change the checksum when pnp is already the linker")
+		}
+	})
+
+	t.Run("yarn configuration does not affect npm bundles", func(t *testing.T) {
+		t.Parallel()
+
+		npm := []tarEntry{
+			{name: "package-lock.json", content: []byte(syntheticPackageLock)},
+			{name: "package.json", content: []byte(`{"name":"root"}`)},
+		}
+		withYarnrc := append(slices.Clone(npm), tarEntry{name: ".yarnrc.yml", content: []byte("nodeLinker: pnp\n")})
+
+		if inspect(t, npm).ChecksumSha256 != inspect(t, withYarnrc).ChecksumSha256 {
+			t.Error(".yarnrc.yml should not change the checksum of an npm bundle")
+		}
+	})
+}
+
+func TestDescribePlaywrightCodeBundleMetadataChanges(t *testing.T) {
+	t.Parallel()
+
+	base := PlaywrightCodeBundleMetadata{
+		Version:           PlaywrightCodeBundleMetadataCurrentVersion,
+		ChecksumSha256:    "aaaa",
+		PlaywrightVersion: "1.47.0",
+		PackageManager:    "pnpm",
+		CacheHash:         "cccc",
+		CacheInputs: &PlaywrightCodeBundleCacheInputs{
+			Lockfile:          "pnpm-lock.yaml",
+			LockfileDigest:    "111111111111",
+			PackageJSONDigest: "222222222222",
+			NpmrcDigest:       "333333333333",
+		},
+		WorkingDir:       ".",
+		Engine:           "node",
+		EngineVersion:    "22",
+		EngineRawVersion: "22",
+		EngineSource:     ".nvmrc",
+		EngineRuleSet:    "embedded",
+	}
+
+	tests := []struct {
+		name   string
+		modify func(prev, next *PlaywrightCodeBundleMetadata)
+		want   []string
+	}{
+		{
+			name:   "source edit only",
+			modify: func(prev, next *PlaywrightCodeBundleMetadata) { next.ChecksumSha256 = "bbbb" },
+			want:   []string{"archive contents changed"},
+		},
+		{
+			name: "playwright upgrade",
+			modify: func(prev, next *PlaywrightCodeBundleMetadata) {
+				next.ChecksumSha256 = "bbbb"
+				next.PlaywrightVersion = "1.49.1"
+				next.CacheHash = "dddd"
+				next.CacheInputs = &PlaywrightCodeBundleCacheInputs{
+					Lockfile:          "pnpm-lock.yaml",
+					LockfileDigest:    "444444444444",
+					PackageJSONDigest: "555555555555",
+					NpmrcDigest:       "333333333333",
+				}
+			},
+			want: []string{
+				"archive contents changed",
+				"playwright 1.47.0 → 1.49.1",
+				"cache hash changed because pnpm-lock.yaml changed, package.json files changed",
+			},
+		},
+		{
+			name: "package manager switch",
+			modify: func(prev, next *PlaywrightCodeBundleMetadata) {
+				next.PackageManager = "npm"
+				next.CacheHash = "dddd"
+				next.CacheInputs = &PlaywrightCodeBundleCacheInputs{
+					Lockfile:          "package-lock.json",
+					LockfileDigest:    "444444444444",
+					PackageJSONDigest: "222222222222",
+					NpmrcDigest:       "333333333333",
+				}
+			},
+			want: []string{
+				"package manager pnpm → npm",
+				"cache hash changed because the lockfile changed from pnpm-lock.yaml to package-lock.json",
+			},
+		},
+		{
+			name: "cache inputs unknown for older metadata",
+			modify: func(prev, next *PlaywrightCodeBundleMetadata) {
+				prev.Version = 7
+				prev.CacheInputs = nil
+				next.CacheHash = "dddd"
+			},
+			want: []string{
+				fmt.Sprintf("metadata format v7 → v%d", PlaywrightCodeBundleMetadataCurrentVersion),
+				"cache hash changed",
+			},
+		},
+		{
+			name: "engine change",
+			modify: func(prev, next *PlaywrightCodeBundleMetadata) {
+				next.EngineVersion = "24"
+				next.EngineRawVersion = "^24"
+				next.EngineSource = "package.json devEngines.runtime"
+			},
+			want: []string{
+				"engine node 22 → node 24",
+				`engine version "22" from .nvmrc → "^24" from package.json devEngines.runtime`,
+			},
+		},
+		{
+			name: "engine removed",
+			modify: func(prev, next *PlaywrightCodeBundleMetadata) {
+				next.Engine = ""
+				next.EngineVersion = ""
+				next.EngineRawVersion = ""
+				next.EngineSource = ""
+				next.EngineRuleSet = ""
+			},
+			want: []string{
+				"engine node 22 → none",
+				`engine version "22" from .nvmrc → none`,
+				"engine rules embedded → none",
+			},
+		},
+		{
+			name:   "nothing changed",
+			modify: func(prev, next *PlaywrightCodeBundleMetadata) {},
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			prev, next := base, base
+			tt.modify(&prev, &next)
+
+			got := describePlaywrightCodeBundleMetadataChanges(&prev, &next)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("changes = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInspectLockfileCacheInputs(t *testing.T) {
+	t.Parallel()
+
+	inspect := func(t *testing.T, entries []tarEntry) *PlaywrightCodeBundleCacheInputs {
+		t.Helper()
+
+		attr := PlaywrightCodeBundlePrebuiltArchiveAttribute{File: buildTarGz(t, entries)}
+		info, err := attr.InspectLockfile("@playwright/test", InspectLockfileOptions{})
+		if err != nil {
+			t.Fatalf("InspectLockfile failed: %v", err)
+		}
+		return info.CacheInputs
+	}
+
+	packageJSON := tarEntry{name: "package.json", content: []byte(`{"name":"test"}`)}
+	base := inspect(t, []tarEntry{
+		{name: "package-lock.json", content: []byte(syntheticPackageLock)},
+		packageJSON,
+	})
+	if base.Lockfile != "package-lock.json" {
+		t.Errorf("Lockfile = %q, want %q", base.Lockfile, "package-lock.json")
+	}
+
+	withNpmrc := inspect(t, []tarEntry{
+		{name: "package-lock.json", content: []byte(syntheticPackageLock)},
+		packageJSON,
+		{name: ".npmrc", content: []byte("registry=https://registry.example.com/\n")},
+	})
+
+	if withNpmrc.LockfileDigest != base.LockfileDigest {
+		t.Error("LockfileDigest should not depend on .npmrc")
+	}
+	if withNpmrc.PackageJSONDigest != base.PackageJSONDigest {
+		t.Error("PackageJSONDigest should not depend on .npmrc")
+	}
+	if withNpmrc.NpmrcDigest == base.NpmrcDigest {
+		t.Error("NpmrcDigest should change when an .npmrc is added")
+	}
+}
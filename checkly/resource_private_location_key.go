@@ -0,0 +1,180 @@
+package checkly
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourcePrivateLocationKey() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: withAPIErrors(resourcePrivateLocationKeyCreate),
+		ReadContext:   withAPIErrors(resourcePrivateLocationKeyRead),
+		DeleteContext: withAPIErrors(resourcePrivateLocationKeyDelete),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultCreateTimeout),
+			Read:   schema.DefaultTimeout(defaultReadTimeout),
+			Delete: schema.DefaultTimeout(defaultDeleteTimeout),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePrivateLocationKeyImport,
+		},
+		Description: "An API key that Checkly agents use to connect to a private location. " +
+			"A private location can have several keys, so that a key can be rotated " +
+			"without downtime: create a new key, deploy it to the agents, then revoke " +
+			"the old key. Changing `rotation_trigger` replaces the key; with " +
+			"`create_before_destroy`, the new key is created, and the resources that " +
+			"depend on it are updated, before the old key is revoked.\n\n" +
+			"Keys can be imported as `<private_location_id>/<key_id>`, for example to " +
+			"revoke the key that was created along with the private location, but " +
+			"`raw_key` is only known for keys created by this resource.",
+		Schema: map[string]*schema.Schema{
+			"private_location_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the private location the key belongs to.",
+			},
+			"rotation_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "An arbitrary value that, when changed, replaces the key with a new one. Use a date or a counter, for example.",
+			},
+			"key_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the key.",
+			},
+			"raw_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The key, to configure the agents with. The API only returns it when the key is created, so it is empty for imported keys.",
+			},
+			"masked_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The key with all but its last characters masked, as shown in the Checkly UI.",
+			},
+		},
+	}
+}
+
+// privateLocationKey is a key of a private location as returned by the API.
+// RawKey is only set when the key is created.
+type privateLocationKey struct {
+	ID        string `json:"id"`
+	RawKey    string `json:"rawKey"`
+	MaskedKey string `json:"maskedKey"`
+}
+
+func privateLocationKeysPath(privateLocationID string) string {
+	return "/v1/private-locations/" + url.PathEscape(privateLocationID) + "/keys"
+}
+
+// encodePrivateLocationKeyID returns the resource ID of a key, which includes
+// the private location, since keys are only addressable through it.
+func encodePrivateLocationKeyID(privateLocationID, keyID string) string {
+	return privateLocationID + "/" + keyID
+}
+
+func decodePrivateLocationKeyID(id string) (privateLocationID, keyID string, err error) {
+	privateLocationID, keyID, ok := strings.Cut(id, "/")
+	if !ok || privateLocationID == "" || keyID == "" {
+		return "", "", fmt.Errorf("invalid private location key ID %q, must be <private_location_id>/<key_id>", id)
+	}
+	return privateLocationID, keyID, nil
+}
+
+func resourcePrivateLocationKeyImport(ctx context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+	privateLocationID, _, err := decodePrivateLocationKeyID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.Set("private_location_id", privateLocationID)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourcePrivateLocationKeyCreate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
+	api, err := apiClientFromMeta(client)
+	if err != nil {
+		return err
+	}
+
+	privateLocationID := d.Get("private_location_id").(string)
+
+	var key privateLocationKey
+	err = api.post(ctx, privateLocationKeysPath(privateLocationID), struct{}{}, &key)
+	if err != nil {
+		return fmt.Errorf("failed to create key for private location %q: %w", privateLocationID, err)
+	}
+
+	d.SetId(encodePrivateLocationKeyID(privateLocationID, key.ID))
+
+	// The raw key can't be read back, so it is only ever set here.
+	d.Set("raw_key", key.RawKey)
+
+	return resourcePrivateLocationKeyRead(ctx, d, client)
+}
+
+func resourcePrivateLocationKeyRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
+	api, err := apiClientFromMeta(client)
+	if err != nil {
+		return err
+	}
+
+	privateLocationID, keyID, err := decodePrivateLocationKeyID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	var pl struct {
+		Keys []privateLocationKey `json:"keys"`
+	}
+	err = api.get(ctx, "/v1/private-locations/"+url.PathEscape(privateLocationID), nil, &pl)
+	if err != nil {
+		if isNotFoundError(err) {
+			// The keys of a deleted private location are gone with it.
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("failed to retrieve private location %q: %w", privateLocationID, err)
+	}
+
+	i := slices.IndexFunc(pl.Keys, func(k privateLocationKey) bool { return k.ID == keyID })
+	if i < 0 {
+		// The key was revoked outside of Terraform.
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("private_location_id", privateLocationID)
+	d.Set("key_id", keyID)
+	d.Set("masked_key", pl.Keys[i].MaskedKey)
+
+	return nil
+}
+
+func resourcePrivateLocationKeyDelete(ctx context.Context, d *schema.ResourceData, client interface{}) error {
+	api, err := apiClientFromMeta(client)
+	if err != nil {
+		return err
+	}
+
+	privateLocationID, keyID, err := decodePrivateLocationKeyID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	err = api.delete(ctx, privateLocationKeysPath(privateLocationID)+"/"+url.PathEscape(keyID))
+	if err != nil && !isNotFoundError(err) {
+		return fmt.Errorf("failed to revoke key %q of private location %q: %w", keyID, privateLocationID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package checkly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestDecodePrivateLocationKeyID(t *testing.T) {
+	t.Parallel()
+
+	privateLocationID, keyID, err := decodePrivateLocationKeyID("pl-1/key-2")
+	if err != nil || privateLocationID != "pl-1" || keyID != "key-2" {
+		t.Errorf("decodePrivateLocationKeyID = %q, %q, %v", privateLocationID, keyID, err)
+	}
+
+	for _, id := range []string{"key-2", "/key-2", "pl-1/"} {
+		if _, _, err := decodePrivateLocationKeyID(id); err == nil {
+			t.Errorf("decodePrivateLocationKeyID(%q) succeeded, want an error", id)
+		}
+	}
+}
+
+func TestResourcePrivateLocationKeyLifecycle(t *testing.T) {
+	t.Parallel()
+
+	keys := []privateLocationKey{{ID: "key-1", MaskedKey: "pl_...1111"}}
+
+	api := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/private-locations/pl-1/keys":
+			keys = append(keys, privateLocationKey{ID: "key-2", MaskedKey: "pl_...2222"})
+			json.NewEncoder(w).Encode(privateLocationKey{ID: "key-2", RawKey: "pl_2222", MaskedKey: "pl_...2222"})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/private-locations/pl-1":
+			json.NewEncoder(w).Encode(map[string]any{"id": "pl-1", "keys": keys})
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/private-locations/pl-1/keys/key-1":
+			keys = keys[1:]
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	meta := &providerMeta{
+		api: api,
+	}
+
+	newKey := resourcePrivateLocationKey().TestResourceData()
+	newKey.Set("private_location_id", "pl-1")
+
+	if err := resourcePrivateLocationKeyCreate(context.Background(), newKey, meta); err != nil {
+		t.Fatalf("resourcePrivateLocationKeyCreate failed: %v", err)
+	}
+	if got := newKey.Id(); got != "pl-1/key-2" {
+		t.Errorf("ID = %q, want %q", got, "pl-1/key-2")
+	}
+	if got := newKey.Get("raw_key"); got != "pl_2222" {
+		t.Errorf("raw_key = %v, want %q", got, "pl_2222")
+	}
+	if got := newKey.Get("masked_key"); got != "pl_...2222" {
+		t.Errorf("masked_key = %v, want %q", got, "pl_...2222")
+	}
+
+	oldKey := resourcePrivateLocationKey().TestResourceData()
+	oldKey.SetId("pl-1/key-1")
+
+	if err := resourcePrivateLocationKeyDelete(context.Background(), oldKey, meta); err != nil {
+		t.Fatalf("resourcePrivateLocationKeyDelete failed: %v", err)
+	}
+
+	// A revoked key is gone from the state on the next read, while the new
+	// key, and its raw value, remain.
+	if err := resourcePrivateLocationKeyRead(context.Background(), oldKey, meta); err != nil {
+		t.Fatalf("resourcePrivateLocationKeyRead failed: %v", err)
+	}
+	if oldKey.Id() != "" {
+		t.Errorf("revoked key is still in the state with ID %q", oldKey.Id())
+	}
+
+	if err := resourcePrivateLocationKeyRead(context.Background(), newKey, meta); err != nil {
+		t.Fatalf("resourcePrivateLocationKeyRead failed: %v", err)
+	}
+	if got := newKey.Get("raw_key"); got != "pl_2222" {
+		t.Errorf("raw_key after read = %v, want %q", got, "pl_2222")
+	}
+}
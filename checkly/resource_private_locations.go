@@ -3,7 +3,6 @@ package checkly
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -12,10 +11,11 @@ import (
 
 func resourcePrivateLocation() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePrivateLocationCreate,
-		Read:   resourcePrivateLocationRead,
-		Update: resourcePrivateLocationUpdate,
-		Delete: resourcePrivateLocationDelete,
+		CreateContext: withAPIErrors(resourcePrivateLocationCreate),
+		ReadContext:   withPrivateLocationHealthWarning(withAPIErrors(resourcePrivateLocationRead)),
+		UpdateContext: withAPIErrors(resourcePrivateLocationUpdate),
+		DeleteContext: withAPIErrors(resourcePrivateLocationDelete),
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -43,7 +43,13 @@ func resourcePrivateLocation() *schema.Resource {
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
-				Description: "Private location API keys.",
+				Description: "The API key created along with the private location. Use `checkly_private_location_key` to create further keys and rotate them.",
+			},
+			"require_healthy_agents": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Warn when planning if checks run in the private location, either directly or through their check group, while no agents are connected to it. The warning comes from refreshing the private location, so it does not appear when planning with `-refresh=false`. Only checks that already exist are taken into account, not checks that are planned along with the private location. The `checkly_private_location_status` data source exposes the agent status for `check` blocks. (Default `false`).",
 			},
 		},
 	}
@@ -57,22 +63,20 @@ func privateLocationFromResourceData(d *schema.ResourceData) (checkly.PrivateLoc
 	}, nil
 }
 
-func resourcePrivateLocationCreate(d *schema.ResourceData, client interface{}) error {
+func resourcePrivateLocationCreate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	pl, err := privateLocationFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("resourcePrivateLocationCreate: translation error: %w", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	result, err := client.(checkly.Client).CreatePrivateLocation(ctx, pl)
 	if err != nil {
-		return fmt.Errorf("CreatePrivateLocation: API error: %w", err)
+		return fmt.Errorf("failed to create private location: %w", err)
 	}
 	d.SetId(result.ID)
 
 	var keys = []string{result.Keys[0].RawKey}
 	d.Set("keys", keys)
-	return resourcePrivateLocationRead(d, client)
+	return resourcePrivateLocationRead(ctx, d, client)
 }
 
 func resourceDataFromPrivateLocation(pl *checkly.PrivateLocation, d *schema.ResourceData) error {
@@ -82,26 +86,22 @@ func resourceDataFromPrivateLocation(pl *checkly.PrivateLocation, d *schema.Reso
 	return nil
 }
 
-func resourcePrivateLocationUpdate(d *schema.ResourceData, client interface{}) error {
+func resourcePrivateLocationUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	pl, err := privateLocationFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("resourcePrivateLocationUpdate: translation error: %w", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	_, err = client.(checkly.Client).UpdatePrivateLocation(ctx, d.Id(), pl)
 	if err != nil {
-		return fmt.Errorf("resourcePrivateLocationUpdate: API error: %w", err)
+		return fmt.Errorf("failed to update private location %q: %w", d.Id(), err)
 	}
-	return resourcePrivateLocationRead(d, client)
+	return resourcePrivateLocationRead(ctx, d, client)
 }
 
-func resourcePrivateLocationRead(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourcePrivateLocationRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	pl, err := client.(checkly.Client).GetPrivateLocation(ctx, d.Id())
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			//if resource is deleted remotely, then mark it as
 			//successfully gone by unsetting it's ID
 			d.SetId("")
@@ -112,12 +112,10 @@ func resourcePrivateLocationRead(d *schema.ResourceData, client interface{}) err
 	return resourceDataFromPrivateLocation(pl, d)
 }
 
-func resourcePrivateLocationDelete(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourcePrivateLocationDelete(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	err := client.(checkly.Client).DeletePrivateLocation(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("resourcePrivateLocationDelete: API error: %w", err)
+		return fmt.Errorf("failed to delete private location %q: %w", d.Id(), err)
 	}
 	return nil
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -13,10 +12,11 @@ import (
 
 func resourceSnippet() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSnippetCreate,
-		Read:   resourceSnippetRead,
-		Update: resourceSnippetUpdate,
-		Delete: resourceSnippetDelete,
+		CreateContext: withAPIErrors(resourceSnippetCreate),
+		ReadContext:   withAPIErrors(resourceSnippetRead),
+		UpdateContext: withAPIErrors(resourceSnippetUpdate),
+		DeleteContext: withAPIErrors(resourceSnippetDelete),
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -35,19 +35,17 @@ func resourceSnippet() *schema.Resource {
 	}
 }
 
-func resourceSnippetCreate(d *schema.ResourceData, client interface{}) error {
+func resourceSnippetCreate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	snippet, err := snippetFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("resourceSnippetCreate: translation error: %w", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	result, err := client.(checkly.Client).CreateSnippet(ctx, snippet)
 	if err != nil {
-		return fmt.Errorf("CreateSnippet: API error: %w", err)
+		return fmt.Errorf("failed to create snippet: %w", err)
 	}
 	d.SetId(fmt.Sprintf("%d", result.ID))
-	return resourceSnippetRead(d, client)
+	return resourceSnippetRead(ctx, d, client)
 }
 
 func snippetFromResourceData(d *schema.ResourceData) (checkly.Snippet, error) {
@@ -79,51 +77,45 @@ func resourceIDToInt(id string) (int64, error) {
 	return res, nil
 }
 
-func resourceSnippetRead(d *schema.ResourceData, client interface{}) error {
+func resourceSnippetRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	ID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
 		return fmt.Errorf("resourceSnippetRead: ID %s is not numeric: %w", d.Id(), err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	snippet, err := client.(checkly.Client).GetSnippet(ctx, ID)
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			//if resource is deleted remotely, then mark it as
 			//successfully gone by unsetting it's ID
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("resourceSnippetRead: API error: %w", err)
+		return fmt.Errorf("failed to retrieve snippet %q: %w", d.Id(), err)
 	}
 	return resourceDataFromSnippet(snippet, d)
 }
 
-func resourceSnippetUpdate(d *schema.ResourceData, client interface{}) error {
+func resourceSnippetUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	snippet, err := snippetFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("resourceSnippetUpdate: translation error: %w", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	_, err = client.(checkly.Client).UpdateSnippet(ctx, snippet.ID, snippet)
 	if err != nil {
-		return fmt.Errorf("resourceSnippetUpdate: API error: %w", err)
+		return fmt.Errorf("failed to update snippet %q: %w", d.Id(), err)
 	}
 	d.SetId(fmt.Sprintf("%d", snippet.ID))
-	return resourceSnippetRead(d, client)
+	return resourceSnippetRead(ctx, d, client)
 }
 
-func resourceSnippetDelete(d *schema.ResourceData, client interface{}) error {
+func resourceSnippetDelete(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	ID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
 		return fmt.Errorf("resourceSnippetDelete: ID %s is not numeric: %w", d.Id(), err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	err = client.(checkly.Client).DeleteSnippet(ctx, ID)
 	if err != nil {
-		return fmt.Errorf("resourceSnippetDelete: API error: %w", err)
+		return fmt.Errorf("failed to delete snippet %q: %w", d.Id(), err)
 	}
 	return nil
 }
@@ -2,10 +2,8 @@ package checkly
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"sort"
-	"strings"
 
 	checkly "github.com/checkly/checkly-go-sdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
@@ -86,10 +84,11 @@ func sslBaselineSeverityRuleSchema(defaultSeverity, description string) *schema.
 
 func resourceSSLMonitor() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceSSLMonitorCreate,
-		Read:   resourceSSLMonitorRead,
-		Update: resourceSSLMonitorUpdate,
-		Delete: resourceSSLMonitorDelete,
+		CreateContext: withAPIErrors(resourceSSLMonitorCreate),
+		ReadContext:   withAPIErrors(resourceSSLMonitorRead),
+		UpdateContext: withAPIErrors(resourceSSLMonitorUpdate),
+		DeleteContext: withAPIErrors(resourceSSLMonitorDelete),
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -342,72 +341,75 @@ func resourceSSLMonitor() *schema.Resource {
 				Required: false,
 				Computed: true,
 			}),
-			"trigger_incident": triggerIncidentAttributeSchema,
+			"trigger_incident":   triggerIncidentAttributeSchema,
+			tagsAllAttributeName: tagsAllAttributeSchema,
 		},
 		CustomizeDiff: customdiff.Sequence(
 			RetryStrategyCustomizeDiff,
 			FrequencyOffsetCustomizeDiff,
+			TagsAllCustomizeDiff,
+			LocationsCustomizeDiff,
 		),
 	}
 }
 
-func resourceSSLMonitorCreate(d *schema.ResourceData, client interface{}) error {
+func resourceSSLMonitorCreate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	monitor, err := sslCheckFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("translation error: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+	defaults := providerDefaultsFromMeta(client)
+	monitor.Tags = defaults.mergeTags(monitor.Tags)
+	monitor.Locations = defaults.locationsFor(monitor.Locations, monitor.PrivateLocations, monitor.GroupID)
+
 	newMonitor, err := client.(checkly.Client).CreateSSLMonitor(ctx, monitor)
 
 	if err != nil {
-		checkJSON, _ := json.Marshal(monitor)
-		return fmt.Errorf("API error 1: %w, Check: %s", err, string(checkJSON))
+		return fmt.Errorf("failed to create SSL monitor: %w", err)
 	}
 	d.SetId(newMonitor.ID)
-	return resourceSSLMonitorRead(d, client)
+	return resourceSSLMonitorRead(ctx, d, client)
 }
 
-func resourceSSLMonitorRead(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceSSLMonitorRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	monitor, err := client.(checkly.Client).GetSSLMonitor(ctx, d.Id())
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			//if resource is deleted remotely, then mark it as
 			//successfully gone by unsetting it's ID
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("API error 2: %w", err)
+		return fmt.Errorf("failed to retrieve SSL monitor %q: %w", d.Id(), err)
 	}
-	return resourceDataFromSSLMonitor(monitor, d)
+	return storeWithProviderDefaults(d, client, func() error {
+		return resourceDataFromSSLMonitor(monitor, d)
+	})
 }
 
-func resourceSSLMonitorUpdate(d *schema.ResourceData, client interface{}) error {
+func resourceSSLMonitorUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	monitor, err := sslCheckFromResourceData(d)
 
 	if err != nil {
 		return fmt.Errorf("translation error: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+	defaults := providerDefaultsFromMeta(client)
+	monitor.Tags = defaults.mergeTags(monitor.Tags)
+	monitor.Locations = defaults.locationsFor(monitor.Locations, monitor.PrivateLocations, monitor.GroupID)
+
 	_, err = client.(checkly.Client).UpdateSSLMonitor(ctx, monitor.ID, monitor)
 	if err != nil {
-		checkJSON, _ := json.Marshal(monitor)
-		return fmt.Errorf("API error 3: Couldn't update check, Error: %w, \nCheck: %s", err, checkJSON)
+		return fmt.Errorf("failed to update SSL monitor %q: %w", d.Id(), err)
 	}
 	d.SetId(monitor.ID)
-	return resourceSSLMonitorRead(d, client)
+	return resourceSSLMonitorRead(ctx, d, client)
 }
 
-func resourceSSLMonitorDelete(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceSSLMonitorDelete(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	if err := client.(checkly.Client).DeleteSSLMonitor(ctx, d.Id()); err != nil {
-		return fmt.Errorf("API error 4: Couldn't delete Check %s, Error: %w", d.Id(), err)
+		return fmt.Errorf("failed to delete SSL monitor %q: %w", d.Id(), err)
 	}
 	return nil
 }
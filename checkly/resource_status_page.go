@@ -12,10 +12,11 @@ import (
 
 func resourceStatusPage() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceStatusPageCreate,
-		Read:   resourceStatusPageRead,
-		Update: resourceStatusPageUpdate,
-		Delete: resourceStatusPageDelete,
+		CreateContext: withAPIErrors(resourceStatusPageCreate),
+		ReadContext:   withAPIErrors(resourceStatusPageRead),
+		UpdateContext: withAPIErrors(resourceStatusPageUpdate),
+		DeleteContext: withAPIErrors(resourceStatusPageDelete),
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -121,19 +122,17 @@ func resourceStatusPage() *schema.Resource {
 	}
 }
 
-func resourceStatusPageCreate(d *schema.ResourceData, client interface{}) error {
+func resourceStatusPageCreate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	statusPage, err := statusPageFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("resourceStatusPageCreate: translation error: %w", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	result, err := client.(checkly.Client).CreateStatusPage(ctx, statusPage)
 	if err != nil {
-		return fmt.Errorf("CreateStatusPage: API error: %w", err)
+		return fmt.Errorf("failed to create status page: %w", err)
 	}
 	d.SetId(result.ID)
-	return resourceStatusPageRead(d, client)
+	return resourceStatusPageRead(ctx, d, client)
 }
 
 func statusPageFromResourceData(d *schema.ResourceData) (checkly.StatusPage, error) {
@@ -219,43 +218,37 @@ func resourceDataFromStatusPage(p *checkly.StatusPage, d *schema.ResourceData) e
 	return nil
 }
 
-func resourceStatusPageRead(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceStatusPageRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	statusPage, err := client.(checkly.Client).GetStatusPage(ctx, d.Id())
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			//if resource is deleted remotely, then mark it as
 			//successfully gone by unsetting it's ID
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("resourceStatusPageRead: API error: %w", err)
+		return fmt.Errorf("failed to retrieve status page %q: %w", d.Id(), err)
 	}
 	return resourceDataFromStatusPage(statusPage, d)
 }
 
-func resourceStatusPageUpdate(d *schema.ResourceData, client interface{}) error {
+func resourceStatusPageUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	statusPage, err := statusPageFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("resourceStatusPageUpdate: translation error: %w", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	_, err = client.(checkly.Client).UpdateStatusPage(ctx, statusPage.ID, statusPage)
 	if err != nil {
-		return fmt.Errorf("resourceStatusPageUpdate: API error: %w", err)
+		return fmt.Errorf("failed to update status page %q: %w", d.Id(), err)
 	}
 	d.SetId(statusPage.ID)
-	return resourceStatusPageRead(d, client)
+	return resourceStatusPageRead(ctx, d, client)
 }
 
-func resourceStatusPageDelete(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceStatusPageDelete(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	err := client.(checkly.Client).DeleteStatusPage(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("resourceStatusPageDelete: API error: %w", err)
+		return fmt.Errorf("failed to delete status page %q: %w", d.Id(), err)
 	}
 	return nil
 }
@@ -3,7 +3,6 @@ package checkly
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -12,10 +11,11 @@ import (
 
 func resourceStatusPageService() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceStatusPageServiceCreate,
-		Read:   resourceStatusPageServiceRead,
-		Update: resourceStatusPageServiceUpdate,
-		Delete: resourceStatusPageServiceDelete,
+		CreateContext: withAPIErrors(resourceStatusPageServiceCreate),
+		ReadContext:   withAPIErrors(resourceStatusPageServiceRead),
+		UpdateContext: withAPIErrors(resourceStatusPageServiceUpdate),
+		DeleteContext: withAPIErrors(resourceStatusPageServiceDelete),
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -31,19 +31,17 @@ func resourceStatusPageService() *schema.Resource {
 	}
 }
 
-func resourceStatusPageServiceCreate(d *schema.ResourceData, client interface{}) error {
+func resourceStatusPageServiceCreate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	service, err := statusPageServiceFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("resourceStatusPageServiceCreate: translation error: %w", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	result, err := client.(checkly.Client).CreateStatusPageService(ctx, service)
 	if err != nil {
-		return fmt.Errorf("CreateStatusPageService: API error: %w", err)
+		return fmt.Errorf("failed to create status page service: %w", err)
 	}
 	d.SetId(result.ID)
-	return resourceStatusPageServiceRead(d, client)
+	return resourceStatusPageServiceRead(ctx, d, client)
 }
 
 func statusPageServiceFromResourceData(d *schema.ResourceData) (checkly.StatusPageService, error) {
@@ -58,43 +56,37 @@ func resourceDataFromStatusPageService(s *checkly.StatusPageService, d *schema.R
 	return nil
 }
 
-func resourceStatusPageServiceRead(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceStatusPageServiceRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	service, err := client.(checkly.Client).GetStatusPageService(ctx, d.Id())
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			//if resource is deleted remotely, then mark it as
 			//successfully gone by unsetting it's ID
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("resourceStatusPageServiceRead: API error: %w", err)
+		return fmt.Errorf("failed to retrieve status page service %q: %w", d.Id(), err)
 	}
 	return resourceDataFromStatusPageService(service, d)
 }
 
-func resourceStatusPageServiceUpdate(d *schema.ResourceData, client interface{}) error {
+func resourceStatusPageServiceUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	service, err := statusPageServiceFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("resourceStatusPageServiceUpdate: translation error: %w", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	_, err = client.(checkly.Client).UpdateStatusPageService(ctx, service.ID, service)
 	if err != nil {
-		return fmt.Errorf("resourceStatusPageServiceUpdate: API error: %w", err)
+		return fmt.Errorf("failed to update status page service %q: %w", d.Id(), err)
 	}
 	d.SetId(service.ID)
-	return resourceStatusPageServiceRead(d, client)
+	return resourceStatusPageServiceRead(ctx, d, client)
 }
 
-func resourceStatusPageServiceDelete(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceStatusPageServiceDelete(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	err := client.(checkly.Client).DeleteStatusPageService(ctx, d.Id())
 	if err != nil {
-		return fmt.Errorf("resourceStatusPageServiceDelete: API error: %w", err)
+		return fmt.Errorf("failed to delete status page service %q: %w", d.Id(), err)
 	}
 	return nil
 }
@@ -2,10 +2,8 @@ package checkly
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"sort"
-	"strings"
 
 	checkly "github.com/checkly/checkly-go-sdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
@@ -14,10 +12,11 @@ import (
 
 func resourceTCPMonitor() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTCPMonitorCreate,
-		Read:   resourceTCPMonitorRead,
-		Update: resourceTCPMonitorUpdate,
-		Delete: resourceTCPMonitorDelete,
+		CreateContext: withAPIErrors(resourceTCPMonitorCreate),
+		ReadContext:   withAPIErrors(resourceTCPMonitorRead),
+		UpdateContext: withAPIErrors(resourceTCPMonitorUpdate),
+		DeleteContext: withAPIErrors(resourceTCPMonitorDelete),
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -216,72 +215,75 @@ func resourceTCPMonitor() *schema.Resource {
 				Required: false,
 				Computed: true,
 			}),
-			"trigger_incident": triggerIncidentAttributeSchema,
+			"trigger_incident":   triggerIncidentAttributeSchema,
+			tagsAllAttributeName: tagsAllAttributeSchema,
 		},
 		CustomizeDiff: customdiff.Sequence(
 			RetryStrategyCustomizeDiff,
 			FrequencyOffsetCustomizeDiff,
+			TagsAllCustomizeDiff,
+			LocationsCustomizeDiff,
 		),
 	}
 }
 
-func resourceTCPMonitorCreate(d *schema.ResourceData, client interface{}) error {
+func resourceTCPMonitorCreate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	monitor, err := tcpCheckFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("translation error: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+	defaults := providerDefaultsFromMeta(client)
+	monitor.Tags = defaults.mergeTags(monitor.Tags)
+	monitor.Locations = defaults.locationsFor(monitor.Locations, monitor.PrivateLocations, monitor.GroupID)
+
 	newMonitor, err := client.(checkly.Client).CreateTCPMonitor(ctx, monitor)
 
 	if err != nil {
-		checkJSON, _ := json.Marshal(monitor)
-		return fmt.Errorf("API error 1: %w, Check: %s", err, string(checkJSON))
+		return fmt.Errorf("failed to create TCP monitor: %w", err)
 	}
 	d.SetId(newMonitor.ID)
-	return resourceTCPMonitorRead(d, client)
+	return resourceTCPMonitorRead(ctx, d, client)
 }
 
-func resourceTCPMonitorRead(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceTCPMonitorRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	monitor, err := client.(checkly.Client).GetTCPMonitor(ctx, d.Id())
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			//if resource is deleted remotely, then mark it as
 			//successfully gone by unsetting it's ID
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("API error 2: %w", err)
+		return fmt.Errorf("failed to retrieve TCP monitor %q: %w", d.Id(), err)
 	}
-	return resourceDataFromTCPMonitor(monitor, d)
+	return storeWithProviderDefaults(d, client, func() error {
+		return resourceDataFromTCPMonitor(monitor, d)
+	})
 }
 
-func resourceTCPMonitorUpdate(d *schema.ResourceData, client interface{}) error {
+func resourceTCPMonitorUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	monitor, err := tcpCheckFromResourceData(d)
 
 	if err != nil {
 		return fmt.Errorf("translation error: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+	defaults := providerDefaultsFromMeta(client)
+	monitor.Tags = defaults.mergeTags(monitor.Tags)
+	monitor.Locations = defaults.locationsFor(monitor.Locations, monitor.PrivateLocations, monitor.GroupID)
+
 	_, err = client.(checkly.Client).UpdateTCPMonitor(ctx, monitor.ID, monitor)
 	if err != nil {
-		checkJSON, _ := json.Marshal(monitor)
-		return fmt.Errorf("API error 3: Couldn't update check, Error: %w, \nCheck: %s", err, checkJSON)
+		return fmt.Errorf("failed to update TCP monitor %q: %w", d.Id(), err)
 	}
 	d.SetId(monitor.ID)
-	return resourceTCPMonitorRead(d, client)
+	return resourceTCPMonitorRead(ctx, d, client)
 }
 
-func resourceTCPMonitorDelete(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceTCPMonitorDelete(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	if err := client.(checkly.Client).DeleteCheck(ctx, d.Id()); err != nil {
-		return fmt.Errorf("API error 4: Couldn't delete Check %s, Error: %w", d.Id(), err)
+		return fmt.Errorf("failed to delete TCP monitor %q: %w", d.Id(), err)
 	}
 	return nil
 }
@@ -2,10 +2,8 @@ package checkly
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"sort"
-	"strings"
 
 	checkly "github.com/checkly/checkly-go-sdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
@@ -14,10 +12,11 @@ import (
 
 func resourceTracerouteMonitor() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTracerouteMonitorCreate,
-		Read:   resourceTracerouteMonitorRead,
-		Update: resourceTracerouteMonitorUpdate,
-		Delete: resourceTracerouteMonitorDelete,
+		CreateContext: withAPIErrors(resourceTracerouteMonitorCreate),
+		ReadContext:   withAPIErrors(resourceTracerouteMonitorRead),
+		UpdateContext: withAPIErrors(resourceTracerouteMonitorUpdate),
+		DeleteContext: withAPIErrors(resourceTracerouteMonitorDelete),
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -221,72 +220,75 @@ func resourceTracerouteMonitor() *schema.Resource {
 				Required: false,
 				Computed: true,
 			}),
-			"trigger_incident": triggerIncidentAttributeSchema,
+			"trigger_incident":   triggerIncidentAttributeSchema,
+			tagsAllAttributeName: tagsAllAttributeSchema,
 		},
 		CustomizeDiff: customdiff.Sequence(
 			RetryStrategyCustomizeDiff,
 			FrequencyOffsetCustomizeDiff,
+			TagsAllCustomizeDiff,
+			LocationsCustomizeDiff,
 		),
 	}
 }
 
-func resourceTracerouteMonitorCreate(d *schema.ResourceData, client interface{}) error {
+func resourceTracerouteMonitorCreate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	monitor, err := tracerouteCheckFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("translation error: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+	defaults := providerDefaultsFromMeta(client)
+	monitor.Tags = defaults.mergeTags(monitor.Tags)
+	monitor.Locations = defaults.locationsFor(monitor.Locations, monitor.PrivateLocations, monitor.GroupID)
+
 	newMonitor, err := client.(checkly.Client).CreateTracerouteMonitor(ctx, monitor)
 
 	if err != nil {
-		checkJSON, _ := json.Marshal(monitor)
-		return fmt.Errorf("API error 1: %w, Check: %s", err, string(checkJSON))
+		return fmt.Errorf("failed to create traceroute monitor: %w", err)
 	}
 	d.SetId(newMonitor.ID)
-	return resourceTracerouteMonitorRead(d, client)
+	return resourceTracerouteMonitorRead(ctx, d, client)
 }
 
-func resourceTracerouteMonitorRead(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceTracerouteMonitorRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	monitor, err := client.(checkly.Client).GetTracerouteMonitor(ctx, d.Id())
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			//if resource is deleted remotely, then mark it as
 			//successfully gone by unsetting it's ID
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("API error 2: %w", err)
+		return fmt.Errorf("failed to retrieve traceroute monitor %q: %w", d.Id(), err)
 	}
-	return resourceDataFromTracerouteMonitor(monitor, d)
+	return storeWithProviderDefaults(d, client, func() error {
+		return resourceDataFromTracerouteMonitor(monitor, d)
+	})
 }
 
-func resourceTracerouteMonitorUpdate(d *schema.ResourceData, client interface{}) error {
+func resourceTracerouteMonitorUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	monitor, err := tracerouteCheckFromResourceData(d)
 
 	if err != nil {
 		return fmt.Errorf("translation error: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+	defaults := providerDefaultsFromMeta(client)
+	monitor.Tags = defaults.mergeTags(monitor.Tags)
+	monitor.Locations = defaults.locationsFor(monitor.Locations, monitor.PrivateLocations, monitor.GroupID)
+
 	_, err = client.(checkly.Client).UpdateTracerouteMonitor(ctx, monitor.ID, monitor)
 	if err != nil {
-		checkJSON, _ := json.Marshal(monitor)
-		return fmt.Errorf("API error 3: Couldn't update check, Error: %w, \nCheck: %s", err, checkJSON)
+		return fmt.Errorf("failed to update traceroute monitor %q: %w", d.Id(), err)
 	}
 	d.SetId(monitor.ID)
-	return resourceTracerouteMonitorRead(d, client)
+	return resourceTracerouteMonitorRead(ctx, d, client)
 }
 
-func resourceTracerouteMonitorDelete(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceTracerouteMonitorDelete(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	if err := client.(checkly.Client).DeleteTracerouteMonitor(ctx, d.Id()); err != nil {
-		return fmt.Errorf("API error 4: Couldn't delete Check %s, Error: %w", d.Id(), err)
+		return fmt.Errorf("failed to delete traceroute monitor %q: %w", d.Id(), err)
 	}
 	return nil
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -13,10 +12,11 @@ import (
 
 func resourceTriggerCheck() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTriggerCheckCreate,
-		Read:   resourceTriggerCheckRead,
-		Delete: resourceTriggerCheckDelete,
-		Update: resourceTriggerCheckUpdate,
+		CreateContext: withAPIErrors(resourceTriggerCheckCreate),
+		ReadContext:   withAPIErrors(resourceTriggerCheckRead),
+		DeleteContext: withAPIErrors(resourceTriggerCheckDelete),
+		UpdateContext: withAPIErrors(resourceTriggerCheckUpdate),
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -65,60 +65,54 @@ func resourceDataFromTriggerCheck(trigger *checkly.TriggerCheck, data *schema.Re
 	return nil
 }
 
-func resourceTriggerCheckCreate(data *schema.ResourceData, client interface{}) error {
+func resourceTriggerCheckCreate(ctx context.Context, data *schema.ResourceData, client interface{}) error {
 	trigger, err := triggerCheckFromResourceData(data)
 	if err != nil {
 		return fmt.Errorf("resourceTriggerCheckCreate: translation error: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	result, err := client.(checkly.Client).CreateTriggerCheck(ctx, trigger.CheckId)
 	if err != nil {
-		return fmt.Errorf("CreateTriggerCheck: API error: %w", err)
+		return fmt.Errorf("failed to create check trigger: %w", err)
 	}
 
 	data.SetId(fmt.Sprintf("%d", result.ID))
 
-	return resourceTriggerCheckRead(data, client)
+	return resourceTriggerCheckRead(ctx, data, client)
 }
 
-func resourceTriggerCheckDelete(data *schema.ResourceData, client interface{}) error {
+func resourceTriggerCheckDelete(ctx context.Context, data *schema.ResourceData, client interface{}) error {
 	trigger, err := triggerCheckFromResourceData(data)
 	if err != nil {
 		return fmt.Errorf("resourceTriggerCheckDelete: translation error: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	err = client.(checkly.Client).DeleteTriggerCheck(ctx, trigger.CheckId)
 	if err != nil {
-		return fmt.Errorf("DeleteTriggerCheck: API error: %w", err)
+		return fmt.Errorf("failed to delete trigger of check %q: %w", trigger.CheckId, err)
 	}
 
 	return nil
 }
 
-func resourceTriggerCheckRead(data *schema.ResourceData, client interface{}) error {
+func resourceTriggerCheckRead(ctx context.Context, data *schema.ResourceData, client interface{}) error {
 	trigger, err := triggerCheckFromResourceData(data)
 	if err != nil {
 		return fmt.Errorf("resourceTriggerCheckRead: ID %s is not numeric: %w", data.Id(), err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
 	result, err := client.(checkly.Client).GetTriggerCheck(ctx, trigger.CheckId)
-	defer cancel()
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			data.SetId("")
 			return nil
 		}
-		return fmt.Errorf("GetTriggerCheck: API error: %w", err)
+		return fmt.Errorf("failed to retrieve trigger of check %q: %w", trigger.CheckId, err)
 	}
 
 	return resourceDataFromTriggerCheck(result, data)
 }
 
-func resourceTriggerCheckUpdate(data *schema.ResourceData, client interface{}) error {
-	return resourceTriggerCheckRead(data, client)
+func resourceTriggerCheckUpdate(ctx context.Context, data *schema.ResourceData, client interface{}) error {
+	return resourceTriggerCheckRead(ctx, data, client)
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -13,10 +12,11 @@ import (
 
 func resourceTriggerGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTriggerGroupCreate,
-		Read:   resourceTriggerGroupRead,
-		Delete: resourceTriggerGroupDelete,
-		Update: resourceTriggerGroupUpdate,
+		CreateContext: withAPIErrors(resourceTriggerGroupCreate),
+		ReadContext:   withAPIErrors(resourceTriggerGroupRead),
+		DeleteContext: withAPIErrors(resourceTriggerGroupDelete),
+		UpdateContext: withAPIErrors(resourceTriggerGroupUpdate),
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -65,59 +65,53 @@ func resourceDataFromTriggerGroup(trigger *checkly.TriggerGroup, data *schema.Re
 	return nil
 }
 
-func resourceTriggerGroupCreate(data *schema.ResourceData, client interface{}) error {
+func resourceTriggerGroupCreate(ctx context.Context, data *schema.ResourceData, client interface{}) error {
 	tc, err := triggerGroupFromResourceData(data)
 	if err != nil {
 		return fmt.Errorf("resourceTriggerGroupCreate: translation error: %w", err)
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	result, err := client.(checkly.Client).CreateTriggerGroup(ctx, tc.GroupId)
 
 	if err != nil {
-		return fmt.Errorf("CreateTriggerGroup: API error: %w", err)
+		return fmt.Errorf("failed to create check group trigger: %w", err)
 	}
 
 	data.SetId(fmt.Sprintf("%d", result.ID))
 
-	return resourceTriggerGroupRead(data, client)
+	return resourceTriggerGroupRead(ctx, data, client)
 }
 
-func resourceTriggerGroupDelete(data *schema.ResourceData, client interface{}) error {
+func resourceTriggerGroupDelete(ctx context.Context, data *schema.ResourceData, client interface{}) error {
 	tc, err := triggerGroupFromResourceData(data)
 	if err != nil {
 		return fmt.Errorf("resourceTriggerGroupDelete: translation error: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
 	err = client.(checkly.Client).DeleteTriggerGroup(ctx, tc.GroupId)
 	if err != nil {
-		return fmt.Errorf("DeleteTriggerGroup: API error: %w", err)
+		return fmt.Errorf("failed to delete trigger of check group %d: %w", tc.GroupId, err)
 	}
 
 	return nil
 }
 
-func resourceTriggerGroupRead(data *schema.ResourceData, client interface{}) error {
+func resourceTriggerGroupRead(ctx context.Context, data *schema.ResourceData, client interface{}) error {
 	trigger, err := triggerGroupFromResourceData(data)
 	if err != nil {
 		return fmt.Errorf("resourceTriggerGroupRead: ID %s is not numeric: %w", data.Id(), err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
 	result, err := client.(checkly.Client).GetTriggerGroup(ctx, trigger.GroupId)
-	defer cancel()
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			data.SetId("")
 			return nil
 		}
-		return fmt.Errorf("GetTriggerGroup: API error: %w", err)
+		return fmt.Errorf("failed to retrieve trigger of check group %d: %w", trigger.GroupId, err)
 	}
 	return resourceDataFromTriggerGroup(result, data)
 }
 
-func resourceTriggerGroupUpdate(data *schema.ResourceData, client interface{}) error {
-	return resourceTriggerCheckRead(data, client)
+func resourceTriggerGroupUpdate(ctx context.Context, data *schema.ResourceData, client interface{}) error {
+	return resourceTriggerCheckRead(ctx, data, client)
 }
@@ -2,10 +2,8 @@ package checkly
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"sort"
-	"strings"
 
 	checkly "github.com/checkly/checkly-go-sdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
@@ -14,10 +12,11 @@ import (
 
 func resourceURLMonitor() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceURLMonitorCreate,
-		Read:   resourceURLMonitorRead,
-		Update: resourceURLMonitorUpdate,
-		Delete: resourceURLMonitorDelete,
+		CreateContext: withAPIErrors(resourceURLMonitorCreate),
+		ReadContext:   withAPIErrors(resourceURLMonitorRead),
+		UpdateContext: withAPIErrors(resourceURLMonitorUpdate),
+		DeleteContext: withAPIErrors(resourceURLMonitorDelete),
+		Timeouts:      resourceTimeouts(),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -195,72 +194,75 @@ func resourceURLMonitor() *schema.Resource {
 				Required:                   false,
 				Computed:                   true,
 			}),
-			"trigger_incident": triggerIncidentAttributeSchema,
+			"trigger_incident":   triggerIncidentAttributeSchema,
+			tagsAllAttributeName: tagsAllAttributeSchema,
 		},
 		CustomizeDiff: customdiff.Sequence(
 			RetryStrategyCustomizeDiff,
 			FrequencyOffsetCustomizeDiff,
+			TagsAllCustomizeDiff,
+			LocationsCustomizeDiff,
 		),
 	}
 }
 
-func resourceURLMonitorCreate(d *schema.ResourceData, client interface{}) error {
+func resourceURLMonitorCreate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	check, err := urlMonitorFromResourceData(d)
 	if err != nil {
 		return fmt.Errorf("translation error: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+	defaults := providerDefaultsFromMeta(client)
+	check.Tags = defaults.mergeTags(check.Tags)
+	check.Locations = defaults.locationsFor(check.Locations, check.PrivateLocations, check.GroupID)
+
 	newCheck, err := client.(checkly.Client).CreateURLMonitor(ctx, check)
 
 	if err != nil {
-		checkJSON, _ := json.Marshal(check)
-		return fmt.Errorf("API error 1: %w, Check: %s", err, string(checkJSON))
+		return fmt.Errorf("failed to create URL monitor: %w", err)
 	}
 	d.SetId(newCheck.ID)
-	return resourceURLMonitorRead(d, client)
+	return resourceURLMonitorRead(ctx, d, client)
 }
 
-func resourceURLMonitorRead(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceURLMonitorRead(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	check, err := client.(checkly.Client).GetURLMonitor(ctx, d.Id())
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFoundError(err) {
 			//if resource is deleted remotely, then mark it as
 			//successfully gone by unsetting it's ID
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("API error 2: %w", err)
+		return fmt.Errorf("failed to retrieve URL monitor %q: %w", d.Id(), err)
 	}
-	return resourceDataFromURLMonitor(check, d)
+	return storeWithProviderDefaults(d, client, func() error {
+		return resourceDataFromURLMonitor(check, d)
+	})
 }
 
-func resourceURLMonitorUpdate(d *schema.ResourceData, client interface{}) error {
+func resourceURLMonitorUpdate(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	check, err := urlMonitorFromResourceData(d)
 
 	if err != nil {
 		return fmt.Errorf("translation error: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+	defaults := providerDefaultsFromMeta(client)
+	check.Tags = defaults.mergeTags(check.Tags)
+	check.Locations = defaults.locationsFor(check.Locations, check.PrivateLocations, check.GroupID)
+
 	_, err = client.(checkly.Client).UpdateURLMonitor(ctx, check.ID, check)
 	if err != nil {
-		checkJSON, _ := json.Marshal(check)
-		return fmt.Errorf("API error 3: Couldn't update check, Error: %w, \nCheck: %s", err, checkJSON)
+		return fmt.Errorf("failed to update URL monitor %q: %w", d.Id(), err)
 	}
 	d.SetId(check.ID)
-	return resourceURLMonitorRead(d, client)
+	return resourceURLMonitorRead(ctx, d, client)
 }
 
-func resourceURLMonitorDelete(d *schema.ResourceData, client interface{}) error {
-	ctx, cancel := context.WithTimeout(context.Background(), apiCallTimeout())
-	defer cancel()
+func resourceURLMonitorDelete(ctx context.Context, d *schema.ResourceData, client interface{}) error {
 	if err := client.(checkly.Client).DeleteCheck(ctx, d.Id()); err != nil {
-		return fmt.Errorf("API error 4: Couldn't delete Check %s, Error: %w", d.Id(), err)
+		return fmt.Errorf("failed to delete URL monitor %q: %w", d.Id(), err)
 	}
 	return nil
 }
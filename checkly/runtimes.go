@@ -0,0 +1,323 @@
+package checkly
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+// runtimeInfo is a runtime as listed by the API.
+type runtimeInfo struct {
+	Name             string            `json:"name"`
+	Description      string            `json:"description"`
+	Stage            string            `json:"stage"`
+	RuntimeEndOfLife string            `json:"runtimeEndOfLife"`
+	Default          bool              `json:"default"`
+	MultiStepSupport bool              `json:"multiStepSupport"`
+	Dependencies     map[string]string `json:"dependencies"`
+}
+
+// deprecated reports whether checks should move off the runtime.
+func (r runtimeInfo) deprecated() bool {
+	return strings.EqualFold(r.Stage, "DEPRECATED") || r.RuntimeEndOfLife != ""
+}
+
+// endOfLife returns when the runtime stops being available, if it is known.
+func (r runtimeInfo) endOfLife() (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, r.RuntimeEndOfLife); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// runtimeCatalog holds the runtimes and the default runtime of the account,
+// and the default runtimes of the check groups that checks belong to. They are
+// looked up once per provider instance, since every check of a plan is
+// validated against them. Failed lookups are not cached.
+type runtimeCatalog struct {
+	mu             sync.Mutex
+	loaded         bool
+	runtimes       []runtimeInfo
+	accountDefault string
+
+	groupsMu sync.Mutex
+	groups   map[int64]string
+}
+
+// load returns the runtimes and the ID of the default runtime of the account.
+func (c *runtimeCatalog) load(ctx context.Context, api *apiClient) ([]runtimeInfo, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.loaded {
+		return c.runtimes, c.accountDefault, nil
+	}
+
+	// The API returns all runtimes at once.
+	var runtimes []runtimeInfo
+	if err := api.get(ctx, "/v1/runtimes", nil, &runtimes); err != nil {
+		return nil, "", fmt.Errorf("failed to list runtimes: %w", err)
+	}
+
+	var account struct {
+		RuntimeID string `json:"runtimeId"`
+	}
+	if err := api.get(ctx, accountValidationPath, nil, &account); err != nil && !isNotFoundError(err) {
+		return nil, "", fmt.Errorf("failed to retrieve the default runtime of the account: %w", err)
+	}
+
+	accountDefault := account.RuntimeID
+	if accountDefault == "" {
+		for _, r := range runtimes {
+			if r.Default {
+				accountDefault = r.Name
+			}
+		}
+	}
+
+	c.runtimes, c.accountDefault, c.loaded = runtimes, accountDefault, true
+
+	return runtimes, accountDefault, nil
+}
+
+// groupRuntime returns the default runtime of the check group, which is empty
+// if the group has none.
+func (c *runtimeCatalog) groupRuntime(ctx context.Context, client checkly.Client, groupID int64) (string, error) {
+	c.groupsMu.Lock()
+	defer c.groupsMu.Unlock()
+
+	if runtimeID, ok := c.groups[groupID]; ok {
+		return runtimeID, nil
+	}
+
+	group, err := client.GetGroup(ctx, groupID)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve check group %d: %w", groupID, err)
+	}
+
+	var runtimeID string
+	if group.RuntimeID != nil {
+		runtimeID = *group.RuntimeID
+	}
+
+	if c.groups == nil {
+		c.groups = make(map[int64]string)
+	}
+	c.groups[groupID] = runtimeID
+
+	return runtimeID, nil
+}
+
+// effectiveRuntime is the runtime a check runs with, along with where it comes
+// from for messages, such as "the default runtime of check group 12".
+type effectiveRuntime struct {
+	ID     string
+	Source string
+}
+
+// resolveRuntime returns the runtime a check with the given runtime ID and
+// group runs with: its own, the default runtime of its group or the default
+// runtime of the account. It returns false when that can't be told yet.
+func resolveRuntime(ctx context.Context, meta *providerMeta, runtimeID string, groupID int64, accountDefault string) (effectiveRuntime, bool, error) {
+	if runtimeID != "" {
+		return effectiveRuntime{ID: runtimeID, Source: "set by runtime_id"}, true, nil
+	}
+
+	if groupID != 0 {
+		groupRuntimeID, err := meta.runtimes.groupRuntime(ctx, meta.Client, groupID)
+		if err != nil {
+			return effectiveRuntime{}, false, err
+		}
+		if groupRuntimeID != "" {
+			return effectiveRuntime{ID: groupRuntimeID, Source: fmt.Sprintf("the default runtime of check group %d", groupID)}, true, nil
+		}
+	}
+
+	if accountDefault != "" {
+		return effectiveRuntime{ID: accountDefault, Source: "the default runtime of the account"}, true, nil
+	}
+
+	return effectiveRuntime{}, false, nil
+}
+
+// validateRuntime checks that the runtime exists, has not reached its end of
+// life, and supports checks of checkType, if it is set. It returns the
+// runtime so that the caller can warn when it is deprecated.
+func validateRuntime(runtimes []runtimeInfo, rt effectiveRuntime, checkType string, now time.Time) (runtimeInfo, error) {
+	i := slices.IndexFunc(runtimes, func(r runtimeInfo) bool { return r.Name == rt.ID })
+	if i < 0 {
+		names := make([]string, len(runtimes))
+		for i, r := range runtimes {
+			names[i] = r.Name
+		}
+		return runtimeInfo{}, fmt.Errorf("runtime %s (%s) does not exist; available runtimes are %s", rt.ID, rt.Source, strings.Join(names, ", "))
+	}
+	r := runtimes[i]
+
+	if eol, ok := r.endOfLife(); ok && !eol.After(now) {
+		return r, fmt.Errorf("runtime %s (%s) reached its end of life on %s", rt.ID, rt.Source, eol.Format(time.DateOnly))
+	}
+
+	if checkType == "MULTI_STEP" && !r.MultiStepSupport {
+		return r, fmt.Errorf("runtime %s does not support MULTI_STEP checks (%s)", rt.ID, rt.Source)
+	}
+
+	return r, nil
+}
+
+// runtimeDeprecationMessage describes why checks should move off a deprecated
+// runtime.
+func runtimeDeprecationMessage(r runtimeInfo, rt effectiveRuntime) string {
+	msg := fmt.Sprintf("Runtime %s (%s) is deprecated", rt.ID, rt.Source)
+	if eol, ok := r.endOfLife(); ok {
+		msg += fmt.Sprintf(" and reaches its end of life on %s", eol.Format(time.DateOnly))
+	}
+	return msg + ". Use a newer runtime, which the checkly_runtimes data source lists."
+}
+
+// runtimeAttributes names the attributes of a resource that decide the
+// runtime it runs with. Groups have no group or type attribute.
+type runtimeAttributes struct {
+	Runtime string
+	Group   string
+	Type    string
+}
+
+var (
+	checkRuntimeAttributes        = runtimeAttributes{Runtime: "runtime_id", Group: "group_id", Type: "type"}
+	checkGroupRuntimeAttributes   = runtimeAttributes{Runtime: "runtime_id"}
+	checkGroupV2RuntimeAttributes = runtimeAttributes{Runtime: defaultRuntimeAttributeName + ".0.runtime_id"}
+)
+
+func (a runtimeAttributes) keys() []string {
+	keys := []string{a.Runtime}
+	for _, k := range []string{a.Group, a.Type} {
+		if k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// fromResource returns the values of the attributes from a diff or data.
+func (a runtimeAttributes) fromResource(d interface{ GetOk(string) (any, bool) }) (runtimeID string, groupID int64, checkType string) {
+	if v, ok := d.GetOk(a.Runtime); ok {
+		runtimeID = v.(string)
+	}
+	if a.Group != "" {
+		if v, ok := d.GetOk(a.Group); ok {
+			groupID = int64(v.(int))
+		}
+	}
+	if a.Type != "" {
+		if v, ok := d.GetOk(a.Type); ok {
+			checkType = v.(string)
+		}
+	}
+	return runtimeID, groupID, checkType
+}
+
+// runtimeCheck resolves and validates the runtime of a check or group. It
+// returns false when there is nothing to validate: for groups without a
+// default runtime, and when the runtime can't be told yet.
+func runtimeCheck(ctx context.Context, meta any, attrs runtimeAttributes, d interface{ GetOk(string) (any, bool) }) (runtimeInfo, effectiveRuntime, bool, error) {
+	m, ok := meta.(*providerMeta)
+	if !ok || m.api == nil {
+		return runtimeInfo{}, effectiveRuntime{}, false, nil
+	}
+
+	runtimeID, groupID, checkType := attrs.fromResource(d)
+
+	// A group without a default runtime leaves the runtime to its checks.
+	if attrs.Group == "" && runtimeID == "" {
+		return runtimeInfo{}, effectiveRuntime{}, false, nil
+	}
+
+	runtimes, accountDefault, err := m.runtimes.load(ctx, m.api)
+	if err != nil {
+		return runtimeInfo{}, effectiveRuntime{}, false, err
+	}
+
+	rt, ok, err := resolveRuntime(ctx, m, runtimeID, groupID, accountDefault)
+	if err != nil || !ok {
+		return runtimeInfo{}, effectiveRuntime{}, false, err
+	}
+
+	r, err := validateRuntime(runtimes, rt, checkType, time.Now())
+	if err != nil {
+		return runtimeInfo{}, effectiveRuntime{}, false, err
+	}
+
+	return r, rt, true, nil
+}
+
+// runtimeCustomizeDiff validates, at plan time, the runtime that a resource
+// runs with. Deprecated runtimes are only logged here, since a diff can't
+// carry warnings; withRuntimeDeprecationWarning reports them when the
+// resource is applied.
+//
+// The runtime is validated when the resource is created, and when any of
+// attrs changes.
+func runtimeCustomizeDiff(attrs runtimeAttributes) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, meta any) error {
+		keys := attrs.keys()
+		if diff.Id() != "" && !diff.HasChanges(keys...) {
+			return nil
+		}
+
+		for _, k := range keys {
+			if !diff.NewValueKnown(k) {
+				return nil
+			}
+		}
+
+		r, rt, ok, err := runtimeCheck(ctx, meta, attrs, diff)
+		if err != nil || !ok {
+			return err
+		}
+
+		if r.deprecated() {
+			tflog.Warn(ctx, runtimeDeprecationMessage(r, rt))
+		}
+
+		return nil
+	}
+}
+
+// withRuntimeDeprecationWarning adds a warning to the result of f, a create
+// or update function, when the resource runs with a deprecated runtime.
+func withRuntimeDeprecationWarning(
+	attrs runtimeAttributes,
+	f func(context.Context, *schema.ResourceData, any) diag.Diagnostics,
+) func(context.Context, *schema.ResourceData, any) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+		diags := f(ctx, d, meta)
+		if diags.HasError() {
+			return diags
+		}
+
+		// The runtime was validated when the resource was planned, so
+		// errors are not reported again.
+		r, rt, ok, _ := runtimeCheck(ctx, meta, attrs, d)
+		if ok && r.deprecated() {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Deprecated runtime",
+				Detail:   runtimeDeprecationMessage(r, rt),
+			})
+		}
+
+		return diags
+	}
+}
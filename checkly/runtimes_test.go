@@ -0,0 +1,172 @@
+package checkly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/checkly/checkly-go-sdk"
+)
+
+func TestRuntimeInfoDeprecated(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		runtime runtimeInfo
+		want    bool
+	}{
+		{runtimeInfo{Name: "2024.09", Stage: "STABLE"}, false},
+		{runtimeInfo{Name: "2022.10", Stage: "DEPRECATED"}, true},
+		{runtimeInfo{Name: "2023.02", Stage: "STABLE", RuntimeEndOfLife: "2025-01-31"}, true},
+	}
+
+	for _, c := range cases {
+		if got := c.runtime.deprecated(); got != c.want {
+			t.Errorf("deprecated() of %+v = %v, want %v", c.runtime, got, c.want)
+		}
+	}
+}
+
+func TestValidateRuntime(t *testing.T) {
+	t.Parallel()
+
+	runtimes := []runtimeInfo{
+		{Name: "2024.09", Stage: "STABLE", MultiStepSupport: true},
+		{Name: "2023.09", Stage: "DEPRECATED", RuntimeEndOfLife: "2026-01-31T00:00:00Z"},
+		{Name: "2022.10", Stage: "DEPRECATED", RuntimeEndOfLife: "2024-01-31"},
+	}
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	group := effectiveRuntime{Source: "the default runtime of check group 12"}
+
+	cases := []struct {
+		name      string
+		id        string
+		checkType string
+		wantErr   string
+	}{
+		{name: "supported", id: "2024.09", checkType: "MULTI_STEP"},
+		{name: "deprecated", id: "2023.09", checkType: "BROWSER"},
+		{name: "unknown", id: "2021.06", checkType: "API", wantErr: "runtime 2021.06 (the default runtime of check group 12) does not exist; available runtimes are 2024.09, 2023.09, 2022.10"},
+		{name: "end of life", id: "2022.10", checkType: "API", wantErr: "reached its end of life on 2024-01-31"},
+		{name: "no multi-step support", id: "2023.09", checkType: "MULTI_STEP", wantErr: "does not support MULTI_STEP checks"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rt := group
+			rt.ID = c.id
+
+			r, err := validateRuntime(runtimes, rt, c.checkType, now)
+			if c.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateRuntime failed: %v", err)
+				}
+				if r.Name != c.id {
+					t.Errorf("validateRuntime returned runtime %q, want %q", r.Name, c.id)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+				t.Errorf("validateRuntime error = %v, want it to contain %q", err, c.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolveRuntime(t *testing.T) {
+	t.Parallel()
+
+	meta := &providerMeta{}
+
+	rt, ok, err := resolveRuntime(context.Background(), meta, "2024.09", 0, "2023.09")
+	if err != nil || !ok || rt.ID != "2024.09" || rt.Source != "set by runtime_id" {
+		t.Errorf("resolveRuntime with runtime_id = %+v, %v, %v", rt, ok, err)
+	}
+
+	rt, ok, err = resolveRuntime(context.Background(), meta, "", 0, "2023.09")
+	if err != nil || !ok || rt.ID != "2023.09" || rt.Source != "the default runtime of the account" {
+		t.Errorf("resolveRuntime with account default = %+v, %v, %v", rt, ok, err)
+	}
+
+	if _, ok, err := resolveRuntime(context.Background(), meta, "", 0, ""); err != nil || ok {
+		t.Errorf("resolveRuntime without any runtime = %v, %v, want false", ok, err)
+	}
+}
+
+// groupClient serves check groups and counts the requests for them.
+type groupClient struct {
+	checkly.Client
+	groups   map[int64]*checkly.Group
+	requests int
+}
+
+func (c *groupClient) GetGroup(_ context.Context, id int64) (*checkly.Group, error) {
+	c.requests++
+	return c.groups[id], nil
+}
+
+func TestResolveRuntimeCachesGroupRuntimes(t *testing.T) {
+	t.Parallel()
+
+	runtimeID := "2023.09"
+	client := &groupClient{groups: map[int64]*checkly.Group{
+		12: {ID: 12, RuntimeID: &runtimeID},
+		13: {ID: 13},
+	}}
+	meta := &providerMeta{Client: client}
+
+	for range 3 {
+		rt, ok, err := resolveRuntime(context.Background(), meta, "", 12, "2024.09")
+		if err != nil || !ok || rt.ID != "2023.09" || rt.Source != "the default runtime of check group 12" {
+			t.Errorf("resolveRuntime with group runtime = %+v, %v, %v", rt, ok, err)
+		}
+
+		rt, ok, err = resolveRuntime(context.Background(), meta, "", 13, "2024.09")
+		if err != nil || !ok || rt.ID != "2024.09" {
+			t.Errorf("resolveRuntime with group without runtime = %+v, %v, %v", rt, ok, err)
+		}
+	}
+
+	if client.requests != 2 {
+		t.Errorf("resolveRuntime retrieved groups %d times, want 2", client.requests)
+	}
+}
+
+func TestRuntimeCatalogLoad(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+	api := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/v1/runtimes":
+			json.NewEncoder(w).Encode([]runtimeInfo{
+				{Name: "2024.09", Default: true},
+				{Name: "2023.09"},
+			})
+		case accountValidationPath:
+			// Older API versions do not report the default runtime.
+			http.NotFound(w, r)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	var catalog runtimeCatalog
+	for range 3 {
+		runtimes, accountDefault, err := catalog.load(context.Background(), api)
+		if err != nil {
+			t.Fatalf("load failed: %v", err)
+		}
+		if len(runtimes) != 2 || accountDefault != "2024.09" {
+			t.Errorf("load = %v, %q", runtimes, accountDefault)
+		}
+	}
+
+	if requests != 2 {
+		t.Errorf("load made %d requests, want 2", requests)
+	}
+}
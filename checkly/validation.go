@@ -4,8 +4,11 @@ import (
 	"cmp"
 	"fmt"
 	"os"
+	"path"
+	"path/filepath"
 	"regexp"
 	"slices"
+	"strings"
 )
 
 func validateOneOf[T comparable](allowed []T) func(val any, key string) (warns []string, errs []error) {
@@ -28,6 +31,28 @@ func validateBetween[T cmp.Ordered](from, to T) func(val any, key string) (warns
 	}
 }
 
+func validateAtLeast[T cmp.Ordered](from T) func(val any, key string) (warns []string, errs []error) {
+	return func(val any, key string) (warns []string, errs []error) {
+		v := val.(T)
+		if v < from {
+			errs = append(errs, fmt.Errorf("%q must be at least %v, got: %v", key, from, v))
+		}
+		return warns, errs
+	}
+}
+
+func validateRegexp() func(val any, key string) (warns []string, errs []error) {
+	return func(val any, key string) (warns []string, errs []error) {
+		v := val.(string)
+
+		if _, err := regexp.Compile(v); err != nil {
+			errs = append(errs, fmt.Errorf("%q must be a valid regular expression, got %q: %w", key, v, err))
+		}
+
+		return warns, errs
+	}
+}
+
 func validateFileExists() func(val any, key string) (warns []string, errs []error) {
 	return func(val any, key string) (warns []string, errs []error) {
 		v := val.(string)
@@ -41,6 +66,48 @@ func validateFileExists() func(val any, key string) (warns []string, errs []erro
 	}
 }
 
+func validateDirectoryExists() func(val any, key string) (warns []string, errs []error) {
+	return func(val any, key string) (warns []string, errs []error) {
+		v := val.(string)
+
+		info, err := os.Stat(v)
+		if os.IsNotExist(err) {
+			errs = append(errs, fmt.Errorf("%q refers to a non-existing directory %q: %w", key, v, err))
+			return warns, errs
+		}
+
+		if err == nil && !info.IsDir() {
+			errs = append(errs, fmt.Errorf("%q refers to %q, which is not a directory", key, v))
+		}
+
+		return warns, errs
+	}
+}
+
+// validateArchiveRelativePath checks that the value is a relative path that
+// stays inside the archive root.
+func validateArchiveRelativePath() func(val any, key string) (warns []string, errs []error) {
+	return func(val any, key string) (warns []string, errs []error) {
+		v := filepath.ToSlash(val.(string))
+
+		if v == "" {
+			errs = append(errs, fmt.Errorf("%q must not be empty; use \".\" for the archive root", key))
+			return warns, errs
+		}
+
+		if path.IsAbs(v) {
+			errs = append(errs, fmt.Errorf("%q must be relative to the archive root, got %q", key, v))
+			return warns, errs
+		}
+
+		if c := path.Clean(v); c == ".." || strings.HasPrefix(c, "../") {
+			errs = append(errs, fmt.Errorf("%q must not point outside the archive root, got %q", key, v))
+		}
+
+		return warns, errs
+	}
+}
+
 // validateGzipArchive checks that the file at the given path is a gzip archive
 // by inspecting the first two bytes (magic number 0x1f 0x8b). If the file
 // appears to be a zip archive instead, the error message says so.
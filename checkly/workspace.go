@@ -0,0 +1,120 @@
+package checkly
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// parsePnpmWorkspacePackages returns the package globs listed in a
+// pnpm-workspace.yaml file.
+func parsePnpmWorkspacePackages(content []byte) ([]string, error) {
+	var workspace struct {
+		Packages []string `yaml:"packages"`
+	}
+
+	if err := yaml.Unmarshal(content, &workspace); err != nil {
+		return nil, fmt.Errorf("failed to parse pnpm-workspace.yaml: %w", err)
+	}
+
+	return workspace.Packages, nil
+}
+
+// parsePackageJSONWorkspaces returns the package globs listed in the
+// "workspaces" field of a package.json, which npm, Yarn and Bun accept either
+// as an array or as an object with a "packages" array.
+func parsePackageJSONWorkspaces(content []byte) ([]string, error) {
+	var pkg struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+	}
+
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil, fmt.Errorf("failed to parse package.json: %w", err)
+	}
+
+	if len(pkg.Workspaces) == 0 {
+		return nil, nil
+	}
+
+	var globs []string
+	if err := json.Unmarshal(pkg.Workspaces, &globs); err == nil {
+		return globs, nil
+	}
+
+	var object struct {
+		Packages []string `json:"packages"`
+	}
+	if err := json.Unmarshal(pkg.Workspaces, &object); err != nil {
+		return nil, fmt.Errorf("failed to parse \"workspaces\" in package.json: %w", err)
+	}
+
+	return object.Packages, nil
+}
+
+// workspaceMatcher decides whether a directory is a member of a workspace.
+type workspaceMatcher struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+// newWorkspaceMatcher compiles workspace globs, which are relative to the
+// workspace root. Globs starting with "!" exclude directories matched by
+// other globs.
+func newWorkspaceMatcher(globs []string) (*workspaceMatcher, error) {
+	m := &workspaceMatcher{}
+
+	for _, glob := range globs {
+		negate := strings.HasPrefix(glob, "!")
+		glob = strings.TrimPrefix(glob, "!")
+
+		glob = cleanArchivePath(strings.TrimSuffix(glob, "/"))
+
+		re, err := regexp.Compile("^" + globToRegexp(glob) + "$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid workspace pattern %q: %w", glob, err)
+		}
+
+		if negate {
+			m.exclude = append(m.exclude, re)
+		} else {
+			m.include = append(m.include, re)
+		}
+	}
+
+	return m, nil
+}
+
+func (m *workspaceMatcher) matches(dir string) bool {
+	matched := false
+	for _, re := range m.include {
+		if re.MatchString(dir) {
+			matched = true
+			break
+		}
+	}
+
+	if !matched {
+		return false
+	}
+
+	for _, re := range m.exclude {
+		if re.MatchString(dir) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// cleanArchivePath normalizes a user-supplied path inside an archive to the
+// form used for tar entry names, with "." standing for the archive root.
+func cleanArchivePath(p string) string {
+	p = filepath.ToSlash(p)
+	p = strings.TrimPrefix(p, "./")
+	return path.Clean(p)
+}
@@ -0,0 +1,119 @@
+package checkly
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParsePnpmWorkspacePackages(t *testing.T) {
+	t.Parallel()
+
+	got, err := parsePnpmWorkspacePackages([]byte("packages:\n  - 'packages/*'\n  - \"!**/test/**\"\ncatalog:\n  react: ^18.0.0\n"))
+	if err != nil {
+		t.Fatalf("parsePnpmWorkspacePackages failed: %v", err)
+	}
+	if want := []string{"packages/*", "!**/test/**"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if _, err := parsePnpmWorkspacePackages([]byte("packages: [")); err == nil {
+		t.Error("expected an error for invalid YAML")
+	}
+}
+
+func TestParsePackageJSONWorkspaces(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "array",
+			input: `{"workspaces":["packages/*","apps/*"]}`,
+			want:  []string{"packages/*", "apps/*"},
+		},
+		{
+			name:  "object",
+			input: `{"workspaces":{"packages":["packages/*"],"nohoist":["**/react"]}}`,
+			want:  []string{"packages/*"},
+		},
+		{
+			name:  "no workspaces",
+			input: `{"name":"app"}`,
+		},
+		{
+			name:    "invalid workspaces",
+			input:   `{"workspaces":"packages/*"}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid JSON",
+			input:   `{`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parsePackageJSONWorkspaces([]byte(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkspaceMatcher(t *testing.T) {
+	t.Parallel()
+
+	m, err := newWorkspaceMatcher([]string{"packages/*", "./apps/**/", "tools/cli", "!**/fixtures/**"})
+	if err != nil {
+		t.Fatalf("newWorkspaceMatcher failed: %v", err)
+	}
+
+	tests := []struct {
+		dir  string
+		want bool
+	}{
+		{"packages/e2e", true},
+		{"packages/e2e/nested", false},
+		{"apps/web", true},
+		{"apps/web/e2e", true},
+		{"tools/cli", true},
+		{"tools/other", false},
+		{"apps/web/fixtures/app", false},
+		{".", false},
+	}
+
+	for _, tt := range tests {
+		if got := m.matches(tt.dir); got != tt.want {
+			t.Errorf("matches(%q) = %v, want %v", tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestCleanArchivePath(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"":                   ".",
+		".":                  ".",
+		"./":                 ".",
+		"packages/e2e/":      "packages/e2e",
+		"./packages/e2e":     "packages/e2e",
+		"packages//e2e/../a": "packages/a",
+	}
+
+	for in, want := range tests {
+		if got := cleanArchivePath(in); got != want {
+			t.Errorf("cleanArchivePath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
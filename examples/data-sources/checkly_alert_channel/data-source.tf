@@ -0,0 +1,32 @@
+# The email alert channel of the on-call team
+data "checkly_alert_channel" "on_call" {
+  type = "EMAIL"
+  name = "on-call@example.com"
+}
+
+# The only Opsgenie alert channel of the account
+data "checkly_alert_channel" "opsgenie" {
+  type = "OPSGENIE"
+}
+
+resource "checkly_check" "homepage" {
+  name      = "Homepage"
+  type      = "API"
+  activated = true
+  frequency = 1
+  locations = ["eu-west-1"]
+
+  alert_channel_subscription {
+    channel_id = data.checkly_alert_channel.on_call.id
+    activated  = true
+  }
+
+  alert_channel_subscription {
+    channel_id = data.checkly_alert_channel.opsgenie.id
+    activated  = true
+  }
+
+  request {
+    url = "https://www.example.com"
+  }
+}
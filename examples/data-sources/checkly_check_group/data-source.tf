@@ -0,0 +1,16 @@
+# A check group managed in another workspace
+data "checkly_check_group" "payments" {
+  name = "Payments"
+}
+
+resource "checkly_check" "checkout" {
+  name      = "Checkout API"
+  type      = "API"
+  activated = true
+  frequency = 5
+  group_id  = data.checkly_check_group.payments.id
+
+  request {
+    url = "https://api.example.com/checkout/health"
+  }
+}
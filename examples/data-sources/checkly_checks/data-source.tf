@@ -0,0 +1,20 @@
+# All activated API checks of the payments team, wherever they are managed
+data "checkly_checks" "payments" {
+  tags                  = ["team:payments", "api"]
+  use_tags_and_operator = true
+  type                  = "API"
+  activated             = true
+}
+
+# Checks whose name starts with "Checkout", of any type
+data "checkly_checks" "checkout" {
+  name_regex = "^Checkout"
+}
+
+output "payments_check_names" {
+  value = data.checkly_checks.payments.checks[*].name
+}
+
+output "checkout_check_ids" {
+  value = data.checkly_checks.checkout.ids
+}
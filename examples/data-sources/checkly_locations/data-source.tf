@@ -0,0 +1,16 @@
+# Every European location that supports IPv6
+data "checkly_locations" "europe" {
+  continent    = "Europe"
+  ipv6_support = true
+}
+
+resource "checkly_url_monitor" "homepage" {
+  name      = "Homepage"
+  activated = true
+  frequency = 1
+  locations = data.checkly_locations.europe.ids
+
+  request {
+    url = "https://welcome.checklyhq.com"
+  }
+}
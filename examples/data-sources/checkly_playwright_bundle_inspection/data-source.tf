@@ -0,0 +1,21 @@
+data "checkly_playwright_bundle_inspection" "app" {
+  file = "${path.module}/app-bundle.tar.gz"
+}
+
+# Fail the plan early when the bundle would run on an unexpected runtime
+resource "checkly_playwright_code_bundle" "app" {
+  prebuilt_archive {
+    file = data.checkly_playwright_bundle_inspection.app.file
+  }
+
+  lifecycle {
+    precondition {
+      condition     = data.checkly_playwright_bundle_inspection.app.engine_version == "22"
+      error_message = "Expected Node.js 22, got ${data.checkly_playwright_bundle_inspection.app.engine_version} from ${data.checkly_playwright_bundle_inspection.app.engine_source}."
+    }
+  }
+}
+
+output "playwright_version" {
+  value = data.checkly_playwright_bundle_inspection.app.playwright_version
+}
@@ -0,0 +1,16 @@
+# A private location owned by the platform team
+data "checkly_private_location" "datacenter" {
+  slug_name = "datacenter-eu"
+}
+
+resource "checkly_check" "internal_api" {
+  name              = "Internal API"
+  type              = "API"
+  activated         = true
+  frequency         = 5
+  private_locations = [data.checkly_private_location.datacenter.slug_name]
+
+  request {
+    url = "https://internal.example.com/health"
+  }
+}
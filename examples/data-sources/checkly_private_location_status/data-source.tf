@@ -0,0 +1,11 @@
+data "checkly_private_location_status" "office" {
+  slug_name = "office"
+}
+
+# Warns when the private location has no agents to run checks
+check "office_agents" {
+  assert {
+    condition     = data.checkly_private_location_status.office.healthy
+    error_message = "No Checkly agents are connected to the office private location."
+  }
+}
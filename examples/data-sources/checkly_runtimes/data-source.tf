@@ -0,0 +1,19 @@
+# Runtimes that are not deprecated and support multi-step checks
+data "checkly_runtimes" "multi_step" {
+  include_deprecated = false
+  multi_step_support = true
+}
+
+resource "checkly_check" "multi_step" {
+  name       = "Checkout flow"
+  type       = "MULTI_STEP"
+  activated  = true
+  frequency  = 10
+  locations  = ["eu-central-1"]
+  runtime_id = data.checkly_runtimes.multi_step.latest_runtime_id
+  script     = file("${path.module}/checkout.spec.js")
+}
+
+output "account_default_runtime" {
+  value = data.checkly_runtimes.multi_step.default_runtime_id
+}
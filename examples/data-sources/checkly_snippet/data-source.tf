@@ -0,0 +1,17 @@
+# A shared setup snippet
+data "checkly_snippet" "sign_in" {
+  name = "Sign in"
+}
+
+resource "checkly_check" "account" {
+  name             = "Account API"
+  type             = "API"
+  activated        = true
+  frequency        = 5
+  locations        = ["eu-west-1"]
+  setup_snippet_id = data.checkly_snippet.sign_in.id
+
+  request {
+    url = "https://api.example.com/account"
+  }
+}
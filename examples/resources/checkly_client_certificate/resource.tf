@@ -9,4 +9,9 @@ resource "checkly_client_certificate" "test" {
   private_key = file("${path.module}/key.pem")
   trusted_ca  = file("${path.module}/ca.pem")
   passphrase  = var.acme_client_certificate_passphrase
+
+  # Upload a renewed certificate before the old one is deleted.
+  lifecycle {
+    create_before_destroy = true
+  }
 }
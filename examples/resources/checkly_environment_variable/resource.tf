@@ -9,3 +9,18 @@ resource "checkly_environment_variable" "variable_2" {
   key   = "API_URL"
   value = "http://localhost:3000"
 }
+
+variable "db_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+# Secret with a write-only value, which requires Terraform 1.11 or later. The
+# value is never stored in the state; bump value_version to update it.
+resource "checkly_environment_variable" "variable_3" {
+  key           = "DB_PASSWORD"
+  value_wo      = var.db_password
+  value_version = 1
+  secret        = true
+}
@@ -0,0 +1,17 @@
+# Environment variables from a map
+resource "checkly_environment_variables" "inline" {
+  variables = {
+    API_URL = "https://api.example.com"
+    API_KEY = "loZd9hOGHDUrGvmW"
+  }
+  secret_keys = ["API_KEY"]
+}
+
+# Every environment variable of the account, from a dotenv file. Variables
+# that are not in the file are deleted.
+resource "checkly_environment_variables" "from_file" {
+  source_file = "${path.module}/checkly.env"
+  secret_keys = ["DB_PASSWORD"]
+  locked_keys = ["API_URL"]
+  exclusive   = true
+}
@@ -21,3 +21,50 @@ resource "checkly_playwright_code_bundle" "example-2" {
     file = "${path.module}/existing-playwright-bundle.tar.gz"
   }
 }
+
+# Let the provider build the bundle from a directory
+resource "checkly_playwright_code_bundle" "example-3" {
+  source_directory {
+    path = "${path.module}/app/"
+    exclude = [
+      "*.md",
+      "screenshots/",
+    ]
+  }
+}
+
+# Pick one of several Playwright projects in a workspace monorepo
+resource "checkly_playwright_code_bundle" "example-4" {
+  source_directory {
+    path = "${path.module}/monorepo/"
+  }
+
+  project_path = "packages/e2e"
+}
+
+# Reject archives that are too large or contain files that should not ship
+resource "checkly_playwright_code_bundle" "example-5" {
+  prebuilt_archive {
+    file = "${path.module}/existing-playwright-bundle.tar.gz"
+  }
+
+  archive_policy {
+    max_compressed_bytes = 50 * 1024 * 1024
+    max_files            = 5000
+    denied_paths = [
+      "node_modules/",
+      "*.har",
+    ]
+  }
+}
+
+# Allow more time for uploading a large archive
+resource "checkly_playwright_code_bundle" "example-6" {
+  prebuilt_archive {
+    file = "${path.module}/large-playwright-bundle.tar.gz"
+  }
+
+  timeouts {
+    create = "1h"
+  }
+}
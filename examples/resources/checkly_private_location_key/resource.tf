@@ -0,0 +1,25 @@
+resource "checkly_private_location" "location" {
+  name      = "New Private Location"
+  slug_name = "new-private-location"
+}
+
+# Change rotation_trigger to rotate the key. The new key is created and handed
+# to the agents before the old key is revoked.
+resource "checkly_private_location_key" "agents" {
+  private_location_id = checkly_private_location.location.id
+  rotation_trigger    = "2026-10"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+resource "kubernetes_secret" "checkly_agent" {
+  metadata {
+    name = "checkly-agent"
+  }
+
+  data = {
+    API_KEY = checkly_private_location_key.agents.raw_key
+  }
+}